@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/repository"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/database"
+	"github.com/sekolahmu/boilerplate-go/pkg/hasher"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// loadConfig memuat konfigurasi dan membuat logger aplikasi yang dipakai oleh
+// semua command. Logger global dipakai oleh logger.FromContext untuk context
+// yang tidak membawa logger milik request.
+func loadConfig() (*config.Config, *zap.Logger, error) {
+	cfg, err := config.Load(configOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading config: %w", err)
+	}
+
+	appLogger, err := logger.New(cfg.Logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error initializing logger: %w", err)
+	}
+	appLogger = appLogger.With(zap.String("app", cfg.App.Name), zap.String("version", cfg.App.Version))
+	zap.ReplaceGlobals(appLogger)
+	for _, warning := range cfg.Warnings {
+		appLogger.Warn("config warning", zap.String("warning", warning))
+	}
+
+	return cfg, appLogger, nil
+}
+
+// withDatabase menjalankan fn untuk command administrasi dengan koneksi
+// database yang ditutup setelah fn selesai
+func withDatabase(fn func(ctx context.Context, cfg *config.Config, db *sql.DB) error) error {
+	cfg, appLogger, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = appLogger.Sync() }()
+	ctx := logger.NewContext(context.Background(), appLogger)
+
+	db, err := database.Open(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %w", err)
+	}
+	defer db.Close()
+
+	return fn(ctx, cfg, db)
+}
+
+// newUserUseCase membuat UserUseCase yang sama seperti pada server, tanpa
+// instrumentasi metrics
+func newUserUseCase(cfg *config.Config, db *sql.DB) (usecase_interface.UserUseCase, error) {
+	passwordHasher, err := newPasswordHasher(cfg.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing password hasher: %w", err)
+	}
+	return usecase.NewUserUseCase(repository.NewUserRepository(db), passwordHasher), nil
+}
+
+// adminContext menjalankan command administrasi sebagai auth.SystemPrincipal,
+// sehingga usecase mengizinkan operator mengisi role user
+func adminContext(ctx context.Context) context.Context {
+	return auth.NewContext(ctx, auth.SystemPrincipal())
+}
+
+// newPasswordHasher membuat PasswordHasher dari konfigurasi password
+func newPasswordHasher(cfg config.PasswordConfig) (hasher.PasswordHasher, error) {
+	return hasher.New(hasher.Options{
+		Algorithm:         cfg.Algorithm,
+		BcryptCost:        cfg.BcryptCost,
+		Argon2Memory:      cfg.Argon2Memory,
+		Argon2Iterations:  cfg.Argon2Iterations,
+		Argon2Parallelism: cfg.Argon2Parallelism,
+		Argon2SaltLength:  cfg.Argon2SaltLength,
+		Argon2KeyLength:   cfg.Argon2KeyLength,
+	})
+}
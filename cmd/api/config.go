@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newConfigCommand membuat command "config" untuk memeriksa konfigurasi
+// efektif tanpa menjalankan server
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the configuration",
+	}
+
+	var format string
+	printCmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Konfigurasi dibaca tanpa validasi agar konfigurasi yang salah
+			// tetap dapat diperiksa
+			cfg, err := config.Read(configOptions)
+			if err != nil {
+				return err
+			}
+
+			var out []byte
+			switch format {
+			case "yaml":
+				out, err = yaml.Marshal(cfg.Settings())
+			case "json":
+				out, err = json.MarshalIndent(cfg.Settings(), "", "  ")
+				out = append(out, '\n')
+			default:
+				return fmt.Errorf("unsupported format %q: use yaml or json", format)
+			}
+			if err != nil {
+				return fmt.Errorf("error encoding config: %w", err)
+			}
+
+			// Sumber dan peringatan ditulis ke stderr agar stdout tetap dapat
+			// di-parse
+			sources := "none (defaults and environment only)"
+			if len(cfg.Sources) > 0 {
+				sources = strings.Join(cfg.Sources, ", ")
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "config files: %s\n", sources)
+			for _, warning := range cfg.Warnings {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+			}
+			if _, err := cmd.OutOrStdout().Write(out); err != nil {
+				return err
+			}
+			return cfg.Validate()
+		},
+	}
+	printCmd.Flags().StringVarP(&format, "format", "o", "yaml", "output format (yaml or json)")
+
+	cmd.AddCommand(printCmd)
+	return cmd
+}
@@ -1,18 +1,14 @@
 package main
 
 import (
-	"database/sql"
 	"fmt"
-	"log"
+	"os"
 
-	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
 	_ "github.com/sekolahmu/boilerplate-go/docs"
 	"github.com/sekolahmu/boilerplate-go/internal/config"
-	"github.com/sekolahmu/boilerplate-go/internal/delivery/http"
-	"github.com/sekolahmu/boilerplate-go/internal/repository"
-	"github.com/sekolahmu/boilerplate-go/internal/usecase"
-	"github.com/swaggo/gin-swagger"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/cli"
+	"github.com/spf13/cobra"
 )
 
 // @title Boilerplate Go API
@@ -20,53 +16,44 @@ import (
 // @description This is a boilerplate Go API using clean architecture
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
-	// Load configuration
-	cfg, err := config.Init()
-	if err != nil {
-		log.Fatalf("Error loading config: %v", err)
-	}
-
-	// Initialize database connection
-	dbConnStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Username,
-		cfg.Database.Password,
-		cfg.Database.DBName,
-		cfg.Database.SSLMode,
-	)
-
-	db, err := sql.Open(cfg.Database.Driver, dbConnStr)
-	if err != nil {
-		log.Fatalf("Error connecting to database: %v", err)
-	}
-	defer db.Close()
-
-	// Initialize repository
-	userRepo := repository.NewUserRepository(db)
-
-	// Initialize usecase
-	userUseCase := usecase.NewUserUseCase(userRepo)
-
-	// Initialize HTTP handler
-	userHandler := http.NewUserHandler(userUseCase)
-
-	// Initialize Gin router
-	router := gin.Default()
-
-	// Swagger documentation
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	// API routes
-	v1 := router.Group("/api/v1")
-	{
-		userHandler.RegisterRoutes(v1)
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", cli.FormatError(err))
+		os.Exit(1)
 	}
+}
 
-	// Start server
-	serverAddr := fmt.Sprintf(":%s", cfg.Server.Port)
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+// configOptions diisi dari flag global --config dan --set
+var configOptions config.Options
+
+// newRootCommand membuat command tree aplikasi. Tanpa subcommand, binary
+// menjalankan "serve" agar deployment lama tetap berjalan.
+func newRootCommand() *cobra.Command {
+	serveCmd := newServeCommand()
+	root := &cobra.Command{
+		Use:           "api",
+		Short:         "Boilerplate Go API server and administration commands",
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE:          serveCmd.RunE,
 	}
+	root.Flags().AddFlagSet(serveCmd.Flags())
+	root.PersistentFlags().StringVar(&configOptions.Path, "config", "",
+		"config file (.env, .yaml, .toml or .json); defaults to $"+config.PathEnv+", then .env or config.yaml")
+	root.PersistentFlags().StringArrayVar(&configOptions.Overrides, "set", nil,
+		"override a config value, e.g. --set server.port=8081 (repeatable)")
+
+	root.AddCommand(
+		serveCmd,
+		newServeGRPCCommand(),
+		newMigrateCommand(),
+		newSeedCommand(),
+		newUsersCommand(),
+		newConfigCommand(),
+	)
+	return root
 }
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/migrations"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/migration"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// newMigrateCommand membuat command "migrate". Migrasi dibaca dari file yang
+// di-embed ke dalam binary, kecuali create yang menulis file baru ke
+// direktori source.
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Run the embedded database migrations",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply all pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: runMigrator(func(m *migration.Migrator, args []string) error {
+				return m.Up()
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Roll back the last N migrations (default 1)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: runMigrator(func(m *migration.Migrator, args []string) error {
+				steps := 1
+				if len(args) > 0 {
+					var err error
+					if steps, err = strconv.Atoi(args[0]); err != nil {
+						return fmt.Errorf("invalid number of steps %q", args[0])
+					}
+				}
+				return m.Down(steps)
+			}),
+		},
+		&cobra.Command{
+			Use:   "goto V",
+			Short: "Migrate up or down to version V",
+			Args:  cobra.ExactArgs(1),
+			RunE: runMigrator(func(m *migration.Migrator, args []string) error {
+				version, err := strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				return m.Goto(uint(version))
+			}),
+		},
+		&cobra.Command{
+			Use:   "version",
+			Short: "Print the current migration version",
+			Args:  cobra.NoArgs,
+			RunE: runMigrator(func(m *migration.Migrator, args []string) error {
+				return nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "force V",
+			Short: "Set the version without running migrations (clears the dirty flag)",
+			Args:  cobra.ExactArgs(1),
+			RunE: runMigrator(func(m *migration.Migrator, args []string) error {
+				version, err := strconv.Atoi(args[0])
+				if err != nil {
+					return fmt.Errorf("invalid version %q", args[0])
+				}
+				return m.Force(version)
+			}),
+		},
+		newMigrateCreateCommand(),
+	)
+	return cmd
+}
+
+// runMigrator membuka Migrator, menjalankan fn, lalu menampilkan versi
+// migrasi setelahnya
+func runMigrator(fn func(m *migration.Migrator, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return withDatabase(func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+			m, err := migration.New(ctx, db, migrations.FS, time.Duration(cfg.Migration.LockTimeout)*time.Second)
+			if err != nil {
+				return err
+			}
+			defer m.Close()
+
+			if err := fn(m, args); err != nil {
+				return fmt.Errorf("error running migrate %s: %w", cmd.Name(), err)
+			}
+
+			version, dirty, err := m.Version()
+			switch {
+			case errors.Is(err, migration.ErrNoVersion):
+				fmt.Fprintln(cmd.OutOrStdout(), "version: none")
+			case err != nil:
+				return fmt.Errorf("error reading migration version: %w", err)
+			case dirty:
+				fmt.Fprintf(cmd.OutOrStdout(), "version: %d (dirty)\n", version)
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "version: %d\n", version)
+			}
+			return nil
+		})
+	}
+}
+
+func newMigrateCreateCommand() *cobra.Command {
+	var dir string
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an empty up/down migration pair",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			up, down, err := migration.Create(dir, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), up)
+			fmt.Fprintln(cmd.OutOrStdout(), down)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "migrations", "migration source directory")
+	return cmd
+}
+
+// migrateOnStart menjalankan migrasi yang belum diterapkan sebelum server
+// mulai menerima request. Advisory lock memastikan hanya satu replika yang
+// menjalankan migrasi pada satu waktu.
+func migrateOnStart(ctx context.Context, db *sql.DB, cfg config.MigrationConfig) error {
+	m, err := migration.New(ctx, db, migrations.FS, time.Duration(cfg.LockTimeout)*time.Second)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	start := time.Now()
+	if err := m.Up(); err != nil {
+		return fmt.Errorf("error running migrations: %w", err)
+	}
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, migration.ErrNoVersion) {
+		return fmt.Errorf("error reading migration version: %w", err)
+	}
+	logger.FromContext(ctx).Info("migrations applied", zap.Uint("version", version), zap.Duration("duration", time.Since(start)))
+	return nil
+}
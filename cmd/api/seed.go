@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/cli"
+	"github.com/spf13/cobra"
+)
+
+// newSeedCommand membuat command "seed" untuk mengisi admin awal dan user
+// demo. Seed aman dijalankan berulang kali.
+func newSeedCommand() *cobra.Command {
+	var input cli.SeedInput
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Create the initial admin and optional demo users",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withDatabase(func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+				userUseCase, err := newUserUseCase(cfg, db)
+				if err != nil {
+					return err
+				}
+				return cli.NewSeeder(userUseCase, cmd.OutOrStdout()).Seed(adminContext(ctx), input)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&input.AdminEmail, "admin-email", "", "email of the admin to create")
+	cmd.Flags().StringVar(&input.AdminName, "admin-name", "Administrator", "name of the admin")
+	cmd.Flags().StringVar(&input.AdminPassword, "admin-password", "", "admin password (generated when empty)")
+	cmd.Flags().IntVar(&input.DemoUsers, "demo-users", 0, "number of demo users to create")
+	return cmd
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	nethttp "net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	grpcdelivery "github.com/sekolahmu/boilerplate-go/internal/delivery/grpc"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/interceptor"
+	httpdelivery "github.com/sekolahmu/boilerplate-go/internal/delivery/http"
+	"github.com/sekolahmu/boilerplate-go/internal/job"
+	"github.com/sekolahmu/boilerplate-go/internal/middleware"
+	"github.com/sekolahmu/boilerplate-go/internal/repository"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+	"github.com/sekolahmu/boilerplate-go/pkg/database"
+	"github.com/sekolahmu/boilerplate-go/pkg/health"
+	"github.com/sekolahmu/boilerplate-go/pkg/lifecycle"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/metrics"
+	"github.com/sekolahmu/boilerplate-go/pkg/tracing"
+	"github.com/spf13/cobra"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// serveOptions menentukan API yang dijalankan. Server HTTP selalu berjalan
+// untuk /healthz, /readyz dan /metrics; http menentukan apakah REST API dan
+// Swagger ikut didaftarkan.
+type serveOptions struct {
+	http           bool
+	grpc           bool
+	migrateOnStart bool
+}
+
+func newServeCommand() *cobra.Command {
+	opts := serveOptions{http: true}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the HTTP API and the gRPC server",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.grpc, "grpc", true, "also start the gRPC server")
+	cmd.Flags().BoolVar(&opts.migrateOnStart, "migrate-on-start", false, "apply pending migrations before starting the servers")
+	return cmd
+}
+
+func newServeGRPCCommand() *cobra.Command {
+	opts := serveOptions{grpc: true}
+	cmd := &cobra.Command{
+		Use:   "serve-grpc",
+		Short: "Start only the gRPC server (the HTTP port serves health and metrics endpoints)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.migrateOnStart, "migrate-on-start", false, "apply pending migrations before starting the servers")
+	return cmd
+}
+
+// serve menginisialisasi dependency lalu menjalankan server HTTP dan gRPC
+// sampai menerima SIGINT/SIGTERM. Error dikembalikan alih-alih memanggil
+// log.Fatalf agar shutdown hook, termasuk menutup koneksi database, tetap
+// dijalankan.
+func serve(opts serveOptions) error {
+	cfg, appLogger, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	app := lifecycle.New(time.Duration(cfg.Server.ShutdownTimeout)*time.Second, appLogger)
+	app.SetDrainDelay(time.Duration(cfg.Server.ShutdownDrainDelay) * time.Second)
+	defer app.Shutdown()
+
+	// Didaftarkan pertama sehingga logger di-flush paling akhir
+	app.AfterStop("logger", func(context.Context) error {
+		// Sync ke stderr dapat gagal pada beberapa platform dan aman diabaikan
+		_ = appLogger.Sync()
+		return nil
+	})
+
+	// Initialize tracing. Provider di-shutdown setelah server berhenti agar
+	// span dari request terakhir tetap terkirim.
+	tracerProvider, err := tracing.New(context.Background(), cfg.Tracing, cfg.App)
+	if err != nil {
+		return fmt.Errorf("error initializing tracing: %w", err)
+	}
+	app.AfterStop("tracer provider", tracerProvider.Shutdown)
+
+	// Initialize database connection. Startup gagal jika database tidak dapat
+	// dihubungi setelah DATABASE_CONNECT_ATTEMPTS percobaan, sehingga pod tidak
+	// pernah menerima request tanpa database.
+	db, err := database.Open(logger.NewContext(context.Background(), appLogger), cfg.Database)
+	if err != nil {
+		return fmt.Errorf("error connecting to database: %w", err)
+	}
+	// Didaftarkan sebelum dependency lain sehingga ditutup setelahnya
+	app.AfterStop("database", func(context.Context) error { return db.Close() })
+
+	if opts.migrateOnStart || cfg.Migration.OnStart {
+		if err := migrateOnStart(logger.NewContext(context.Background(), appLogger), db, cfg.Migration); err != nil {
+			return err
+		}
+	}
+
+	// Background job dihentikan setelah server berhenti menerima request dan
+	// ditunggu sampai selesai sebelum koneksi database ditutup
+	jobs := job.NewGroup(logger.NewContext(context.Background(), appLogger.With(zap.String("component", "job"))))
+	app.AfterStop("background jobs", jobs.Stop)
+
+	// Initialize health registry. Readiness HTTP dan health gRPC memakai
+	// pengecekan yang sama dan langsung gagal saat shutdown dimulai agar load
+	// balancer berhenti mengirim request sebelum server dihentikan.
+	healthRegistry := health.NewRegistry(
+		time.Duration(cfg.Health.CheckTimeout)*time.Second,
+		time.Duration(cfg.Health.CacheTTL)*time.Second,
+	)
+	healthRegistry.Register("database", health.PingCheck(db))
+	app.BeforeStop("health", func(context.Context) error {
+		healthRegistry.Shutdown()
+		return nil
+	})
+
+	// Initialize metrics
+	var appMetrics *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		appMetrics = metrics.New()
+		if err := appMetrics.RegisterDBStats(db, cfg.Database.DBName); err != nil {
+			return fmt.Errorf("error registering database metrics: %w", err)
+		}
+	}
+
+	// Initialize repository
+	userRepo := repository.NewUserRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	transactor := repository.NewTransactor(db)
+	if appMetrics != nil {
+		userRepo = repository.NewInstrumentedUserRepository(userRepo, appMetrics)
+		refreshTokenRepo = repository.NewInstrumentedRefreshTokenRepository(refreshTokenRepo, appMetrics)
+		roleRepo = repository.NewInstrumentedRoleRepository(roleRepo, appMetrics)
+	}
+
+	// Initialize password hasher
+	passwordHasher, err := newPasswordHasher(cfg.Password)
+	if err != nil {
+		return fmt.Errorf("error initializing password hasher: %w", err)
+	}
+
+	// Initialize token manager
+	tokenManager, err := auth.NewTokenManager(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("error initializing token manager: %w", err)
+	}
+
+	// Initialize usecase
+	userUseCase := usecase.NewUserUseCase(userRepo, passwordHasher)
+	authUseCase := usecase.NewAuthUseCase(userUseCase, refreshTokenRepo, roleRepo, transactor, tokenManager)
+
+	// Start purge job for soft deleted users
+	if cfg.SoftDelete.RetentionDays > 0 {
+		purgeJob := job.NewUserPurgeJob(userUseCase,
+			time.Duration(cfg.SoftDelete.RetentionDays)*24*time.Hour,
+			time.Duration(cfg.SoftDelete.PurgeInterval)*time.Second)
+		jobs.Go(purgeJob.Run)
+	}
+
+	// Initialize pagination cursor codec
+	cursorCodec, err := cursor.NewCodec(cfg.Pagination.CursorSecret)
+	if err != nil {
+		return fmt.Errorf("error initializing cursor codec: %w", err)
+	}
+
+	// Initialize HTTP handler
+	userHandler := httpdelivery.NewUserHandler(userUseCase, cursorCodec)
+	authHandler := httpdelivery.NewAuthHandler(authUseCase)
+	healthHandler := httpdelivery.NewHealthHandler(healthRegistry, cfg.Health)
+
+	// Initialize Gin router
+	router := gin.New()
+	router.Use(middleware.RequestID(), tracing.Middleware(cfg.App.Name), middleware.AccessLog(appLogger))
+	if appMetrics != nil {
+		router.Use(middleware.Metrics(appMetrics))
+	}
+	router.Use(middleware.Recovery(), middleware.ErrorHandler())
+
+	// Prometheus metrics
+	if appMetrics != nil {
+		router.GET(cfg.Metrics.Path, gin.WrapH(appMetrics.Handler()))
+	}
+
+	// Liveness dan readiness probe
+	healthHandler.RegisterRoutes(router)
+
+	if opts.http {
+		// Swagger documentation
+		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+		// API routes
+		v1 := router.Group("/api/v1")
+		{
+			authHandler.RegisterRoutes(v1)
+			userHandler.RegisterRoutes(v1, tokenManager)
+		}
+	}
+
+	app.AddServer(lifecycle.HTTPServer("HTTP server", &nethttp.Server{
+		Addr:         fmt.Sprintf(":%s", cfg.Server.Port),
+		Handler:      router,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+	}))
+
+	if !opts.grpc {
+		return app.Run(context.Background())
+	}
+
+	// Initialize gRPC server
+	interceptors, err := interceptor.NewChain(cfg.GRPC, appLogger, tokenManager, grpcdelivery.AllowedMethods(cfg.GRPC)...)
+	if err != nil {
+		return fmt.Errorf("error initializing gRPC interceptors: %w", err)
+	}
+	interceptors.SkipDeadline(grpcdelivery.StreamMethods()...)
+	if appMetrics != nil {
+		interceptors.WithMetrics(appMetrics)
+	}
+
+	// Stats handler OpenTelemetry membaca traceparent dari metadata dan
+	// berjalan sebelum interceptor sehingga log gRPC membawa trace_id
+	grpcOptions := append(interceptors.ServerOptions(), tracing.ServerOption())
+	grpcServer := grpc.NewServer(grpcOptions...)
+	grpcdelivery.NewUserServer(userUseCase, cursorCodec).Register(grpcServer)
+
+	// Status health gRPC mengikuti health registry dan diubah menjadi
+	// NOT_SERVING sebelum server dihentikan agar load balancer berhenti
+	// mengirim request
+	healthChecker := grpcdelivery.NewHealthChecker(healthRegistry,
+		time.Duration(cfg.GRPC.HealthCheckInterval)*time.Second)
+	healthChecker.Register(grpcServer)
+	jobs.Go(healthChecker.Run)
+	app.BeforeStop("gRPC health", func(context.Context) error {
+		healthChecker.Shutdown()
+		return nil
+	})
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC.Port))
+	if err != nil {
+		return fmt.Errorf("error listening on gRPC port: %w", err)
+	}
+	app.AddServer(lifecycle.GRPCServer("gRPC server", grpcServer, grpcListener))
+
+	return app.Run(context.Background())
+}
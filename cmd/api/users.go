@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/cli"
+	"github.com/spf13/cobra"
+)
+
+// newUsersCommand membuat command "users" untuk administrasi user tanpa
+// melalui API
+func newUsersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage users",
+	}
+	cmd.AddCommand(
+		newUsersCreateCommand(),
+		newUsersListCommand(),
+		&cobra.Command{
+			Use:   "delete ID",
+			Short: "Soft delete a user",
+			Args:  cobra.ExactArgs(1),
+			RunE: runUserCommand(func(ctx context.Context, users *cli.UserCommand, args []string) error {
+				return users.Delete(ctx, args[0])
+			}),
+		},
+		newUsersResetPasswordCommand(),
+	)
+	return cmd
+}
+
+func newUsersCreateCommand() *cobra.Command {
+	var input cli.CreateUserInput
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user (a random password is generated when --password is empty)",
+		Args:  cobra.NoArgs,
+		RunE: runUserCommand(func(ctx context.Context, users *cli.UserCommand, args []string) error {
+			_, err := users.Create(ctx, input)
+			return err
+		}),
+	}
+	cmd.Flags().StringVar(&input.Email, "email", "", "email address")
+	cmd.Flags().StringVar(&input.Name, "name", "", "display name")
+	cmd.Flags().StringVar(&input.Password, "password", "", "password")
+	cmd.Flags().StringVar(&input.Role, "role", "", "role (admin or user, default user)")
+	_ = cmd.MarkFlagRequired("email")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newUsersListCommand() *cobra.Command {
+	input := cli.ListUsersInput{Limit: 50}
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users, newest first",
+		Args:  cobra.NoArgs,
+		RunE: runUserCommand(func(ctx context.Context, users *cli.UserCommand, args []string) error {
+			return users.List(ctx, input)
+		}),
+	}
+	cmd.Flags().StringVarP(&input.Search, "search", "q", "", "search in email and name")
+	cmd.Flags().IntVar(&input.Limit, "limit", input.Limit, "maximum number of users")
+	cmd.Flags().BoolVar(&input.IncludeDeleted, "include-deleted", false, "include soft deleted users")
+	return cmd
+}
+
+func newUsersResetPasswordCommand() *cobra.Command {
+	var password string
+	cmd := &cobra.Command{
+		Use:   "reset-password ID",
+		Short: "Set a new password (a random password is generated when --password is empty)",
+		Args:  cobra.ExactArgs(1),
+		RunE: runUserCommand(func(ctx context.Context, users *cli.UserCommand, args []string) error {
+			return users.ResetPassword(ctx, args[0], password)
+		}),
+	}
+	cmd.Flags().StringVar(&password, "password", "", "new password")
+	return cmd
+}
+
+func runUserCommand(fn func(ctx context.Context, users *cli.UserCommand, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return withDatabase(func(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+			userUseCase, err := newUserUseCase(cfg, db)
+			if err != nil {
+				return err
+			}
+			return fn(adminContext(ctx), cli.NewUserCommand(userUseCase, cmd.OutOrStdout()), args)
+		})
+	}
+}
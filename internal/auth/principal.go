@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// Principal adalah identitas user yang sudah terautentikasi beserta role dan
+// permission yang berlaku saat access token diterbitkan
+type Principal struct {
+	UserID      string
+	Email       string
+	Role        string
+	Permissions []string
+}
+
+// HasPermission memeriksa apakah principal memiliki permission tertentu
+func (p *Principal) HasPermission(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin bernilai true jika principal memiliki role admin
+func (p *Principal) IsAdmin() bool {
+	return p.Role == entity.RoleAdmin
+}
+
+// CanAccessUser bernilai true jika principal adalah admin atau pemilik record
+func (p *Principal) CanAccessUser(userID string) bool {
+	return p.IsAdmin() || p.UserID == userID
+}
+
+// SystemPrincipal adalah principal untuk command administrasi seperti
+// "users create" dan "seed" yang dijalankan operator dengan akses langsung
+// ke database, sehingga diperlakukan sebagai admin
+func SystemPrincipal() *Principal {
+	return &Principal{UserID: "system", Role: entity.RoleAdmin}
+}
+
+type principalKey struct{}
+
+// NewContext menyimpan principal ke dalam context
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext mengambil principal dari context. Bernilai false jika request
+// tidak terautentikasi.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
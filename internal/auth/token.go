@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	// TokenType adalah tipe token yang dikembalikan ke client
+	TokenType = "Bearer"
+
+	refreshTokenBytes = 32
+)
+
+var (
+	ErrInvalidToken = apperror.Unauthorized("invalid_token", "invalid or expired access token")
+	ErrMissingToken = apperror.Unauthorized("missing_token", "missing bearer token")
+)
+
+// Claims adalah payload access token
+type Claims struct {
+	Email       string   `json:"email"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager menerbitkan dan memverifikasi access token
+type TokenManager struct {
+	method          jwt.SigningMethod
+	signKey         interface{}
+	verifyKey       interface{}
+	issuer          string
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+// NewTokenManager membuat TokenManager berdasarkan konfigurasi auth
+func NewTokenManager(cfg config.AuthConfig) (*TokenManager, error) {
+	m := &TokenManager{
+		issuer:          cfg.Issuer,
+		accessTokenTTL:  time.Duration(cfg.AccessTokenTTL) * time.Second,
+		refreshTokenTTL: time.Duration(cfg.RefreshTokenTTL) * time.Second,
+	}
+	if m.accessTokenTTL <= 0 {
+		return nil, fmt.Errorf("auth access token ttl must be positive")
+	}
+	if m.refreshTokenTTL <= 0 {
+		return nil, fmt.Errorf("auth refresh token ttl must be positive")
+	}
+
+	switch strings.ToUpper(cfg.JWTAlgorithm) {
+	case "", strings.ToUpper(AlgorithmHS256):
+		if len(cfg.JWTSecret) < 32 {
+			return nil, fmt.Errorf("auth jwt secret must be at least 32 bytes for HS256")
+		}
+		m.method = jwt.SigningMethodHS256
+		m.signKey = []byte(cfg.JWTSecret)
+		m.verifyKey = []byte(cfg.JWTSecret)
+	case strings.ToUpper(AlgorithmEdDSA):
+		pem, err := os.ReadFile(cfg.JWTPrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading jwt private key: %w", err)
+		}
+		key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt private key: %w", err)
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt private key is not an ed25519 key")
+		}
+		m.method = jwt.SigningMethodEdDSA
+		m.signKey = privateKey
+		m.verifyKey = privateKey.Public()
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", cfg.JWTAlgorithm)
+	}
+
+	return m, nil
+}
+
+// IssueAccessToken menerbitkan access token untuk user beserta permission
+// dari role-nya. Perubahan role baru berlaku setelah token di-refresh.
+func (m *TokenManager) IssueAccessToken(user *entity.User, permissions []string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.accessTokenTTL)
+
+	claims := Claims{
+		Email:       user.Email,
+		Role:        user.Role,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    m.issuer,
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseAccessToken memverifikasi access token dan mengembalikan principal-nya
+func (m *TokenManager) ParseAccessToken(tokenString string) (*Principal, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return m.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{m.method.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidToken.WithCause(err)
+	}
+	if claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		UserID:      claims.Subject,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		Permissions: claims.Permissions,
+	}, nil
+}
+
+// RefreshTokenTTL mengembalikan masa berlaku refresh token
+func (m *TokenManager) RefreshTokenTTL() time.Duration {
+	return m.refreshTokenTTL
+}
+
+// GenerateRefreshToken membuat refresh token acak beserta hash yang disimpan di database
+func GenerateRefreshToken() (token string, tokenHash string, err error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("error generating refresh token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken menghasilkan hash SHA-256 dari refresh token mentah
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExtractBearerToken mengambil token dari header Authorization "Bearer <token>"
+func ExtractBearerToken(header string) (string, error) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, TokenType) || strings.TrimSpace(token) == "" {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuthConfig() config.AuthConfig {
+	return config.AuthConfig{
+		JWTAlgorithm:    AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	}
+}
+
+func TestTokenManager_HS256(t *testing.T) {
+	manager, err := NewTokenManager(testAuthConfig())
+	require.NoError(t, err)
+
+	user := &entity.User{ID: "user-id", Email: "test@example.com", Role: entity.RoleUser}
+	token, expiresAt, err := manager.IssueAccessToken(user, []string{"users:read"})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+
+	principal, err := manager.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", principal.UserID)
+	assert.Equal(t, "test@example.com", principal.Email)
+	assert.Equal(t, entity.RoleUser, principal.Role)
+	assert.True(t, principal.HasPermission("users:read"))
+	assert.False(t, principal.HasPermission("users:delete"))
+}
+
+func TestTokenManager_EdDSA(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "jwt.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	cfg := testAuthConfig()
+	cfg.JWTAlgorithm = AlgorithmEdDSA
+	cfg.JWTPrivateKeyPath = keyPath
+	manager, err := NewTokenManager(cfg)
+	require.NoError(t, err)
+
+	token, _, err := manager.IssueAccessToken(&entity.User{ID: "user-id"}, nil)
+	require.NoError(t, err)
+
+	principal, err := manager.ParseAccessToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", principal.UserID)
+
+	// Token HS256 tidak boleh diterima oleh manager EdDSA
+	hsManager, err := NewTokenManager(testAuthConfig())
+	require.NoError(t, err)
+	hsToken, _, err := hsManager.IssueAccessToken(&entity.User{ID: "user-id"}, nil)
+	require.NoError(t, err)
+	_, err = manager.ParseAccessToken(hsToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenManager_RejectsInvalidTokens(t *testing.T) {
+	manager, err := NewTokenManager(testAuthConfig())
+	require.NoError(t, err)
+
+	otherCfg := testAuthConfig()
+	otherCfg.JWTSecret = "another-secret-that-is-at-least-32-bytes"
+	otherManager, err := NewTokenManager(otherCfg)
+	require.NoError(t, err)
+
+	forged, _, err := otherManager.IssueAccessToken(&entity.User{ID: "user-id"}, nil)
+	require.NoError(t, err)
+	_, err = manager.ParseAccessToken(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = manager.ParseAccessToken("not-a-jwt")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	manager.accessTokenTTL = -time.Minute
+	expired, _, err := manager.IssueAccessToken(&entity.User{ID: "user-id"}, nil)
+	require.NoError(t, err)
+	_, err = manager.ParseAccessToken(expired)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestNewTokenManager_RejectsShortSecret(t *testing.T) {
+	cfg := testAuthConfig()
+	cfg.JWTSecret = "short"
+	_, err := NewTokenManager(cfg)
+	assert.Error(t, err)
+}
+
+func TestExtractBearerToken(t *testing.T) {
+	token, err := ExtractBearerToken("Bearer abc.def.ghi")
+	require.NoError(t, err)
+	assert.Equal(t, "abc.def.ghi", token)
+
+	token, err = ExtractBearerToken("bearer abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", token)
+
+	for _, header := range []string{"", "Bearer", "Bearer ", "Basic abc"} {
+		_, err := ExtractBearerToken(header)
+		assert.ErrorIs(t, err, ErrMissingToken, header)
+	}
+}
+
+func TestGenerateRefreshToken(t *testing.T) {
+	token, hash, err := GenerateRefreshToken()
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashRefreshToken(token))
+
+	other, _, err := GenerateRefreshToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
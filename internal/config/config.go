@@ -1,64 +1,279 @@
 package config
 
 import (
-	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
+// Nilai AppConfig.Env yang dikenal. Env lain tetap dapat dipakai sebagai
+// nama profile.
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// redacted menggantikan nilai secret pada Settings
+const redacted = "[REDACTED]"
+
 type Config struct {
-	App      AppConfig      `mapstructure:"app"`
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Logger   LoggerConfig   `mapstructure:"logger"`
+	App        AppConfig        `mapstructure:"app"`
+	Server     ServerConfig     `mapstructure:"server"`
+	GRPC       GRPCConfig       `mapstructure:"grpc"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Logger     LoggerConfig     `mapstructure:"logger"`
+	Password   PasswordConfig   `mapstructure:"password"`
+	Auth       AuthConfig       `mapstructure:"auth"`
+	Pagination PaginationConfig `mapstructure:"pagination"`
+	SoftDelete SoftDeleteConfig `mapstructure:"soft_delete"`
+	Metrics    MetricsConfig    `mapstructure:"metrics"`
+	Tracing    TracingConfig    `mapstructure:"tracing"`
+	Health     HealthConfig     `mapstructure:"health"`
+	Migration  MigrationConfig  `mapstructure:"migration"`
+
+	// Sources adalah file konfigurasi yang dibaca oleh Load, berurutan
+	Sources []string `mapstructure:"-"`
+	// Warnings berisi variabel .env yang sudah diganti namanya atau tidak
+	// dikenal, untuk dicatat di log saat start
+	Warnings []string `mapstructure:"-"`
 }
 
 type AppConfig struct {
-	Name    string `mapstructure:"name"`
+	Name    string `mapstructure:"name" validate:"required"`
 	Version string `mapstructure:"version"`
-	Env     string `mapstructure:"env"`
+	Env     string `mapstructure:"env" validate:"required"`
 }
 
+// ServerConfig mengatur server HTTP. Semua timeout dalam detik;
+// ShutdownTimeout membatasi waktu graceful shutdown seluruh aplikasi dan
+// ShutdownDrainDelay adalah jeda antara readiness gagal dan server berhenti
+// menerima koneksi baru.
 type ServerConfig struct {
-	Port         string `mapstructure:"port"`
-	ReadTimeout  int    `mapstructure:"read_timeout"`
-	WriteTimeout int    `mapstructure:"write_timeout"`
+	Port               string `mapstructure:"port" validate:"required"`
+	ReadTimeout        int    `mapstructure:"read_timeout"`
+	WriteTimeout       int    `mapstructure:"write_timeout"`
+	ShutdownTimeout    int    `mapstructure:"shutdown_timeout" validate:"gt=0"`
+	ShutdownDrainDelay int    `mapstructure:"shutdown_drain_delay" validate:"gte=0,ltfield=ShutdownTimeout"`
+}
+
+// GRPCConfig mengatur server gRPC yang berjalan berdampingan dengan server HTTP.
+// DefaultTimeout dipakai untuk request tanpa deadline dan MaxTimeout menjadi
+// batas atas deadline dari client (dalam detik, 0 berarti tidak dibatasi).
+// HealthCheckInterval (dalam detik) mengatur seberapa sering status
+// grpc.health.v1.Health diperbarui dari health registry. Reflection mati secara
+// default dan tetap membutuhkan token kecuali ReflectionPublic diaktifkan.
+type GRPCConfig struct {
+	Port                string `mapstructure:"port" validate:"required"`
+	DefaultTimeout      int    `mapstructure:"default_timeout" validate:"gte=0"`
+	MaxTimeout          int    `mapstructure:"max_timeout" validate:"gte=0"`
+	Reflection          bool   `mapstructure:"reflection"`
+	ReflectionPublic    bool   `mapstructure:"reflection_public"`
+	HealthCheckInterval int    `mapstructure:"health_check_interval" validate:"gt=0"`
 }
 
+// DatabaseConfig mengatur koneksi dan connection pool database. Durasi dalam
+// detik; nilai 0 pada pengaturan pool dan timeout berarti tidak dibatasi.
+// ConnectAttempts adalah jumlah percobaan ping saat startup sebelum aplikasi
+// berhenti dengan error.
 type DatabaseConfig struct {
-	Driver   string `mapstructure:"driver"`
-	Host     string `mapstructure:"host"`
-	Port     string `mapstructure:"port"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	DBName   string `mapstructure:"dbname"`
-	SSLMode  string `mapstructure:"sslmode"`
+	Driver           string `mapstructure:"driver" validate:"required"`
+	Host             string `mapstructure:"host" validate:"required"`
+	Port             string `mapstructure:"port" validate:"required"`
+	Username         string `mapstructure:"username" validate:"required"`
+	Password         string `mapstructure:"password"`
+	DBName           string `mapstructure:"dbname" validate:"required"`
+	SSLMode          string `mapstructure:"sslmode"`
+	MaxOpenConns     int    `mapstructure:"max_open_conns" validate:"gte=0"`
+	MaxIdleConns     int    `mapstructure:"max_idle_conns" validate:"gte=0"`
+	ConnMaxLifetime  int    `mapstructure:"conn_max_lifetime"`
+	ConnMaxIdleTime  int    `mapstructure:"conn_max_idle_time"`
+	ConnectTimeout   int    `mapstructure:"connect_timeout"`
+	StatementTimeout int    `mapstructure:"statement_timeout"`
+	ConnectAttempts  int    `mapstructure:"connect_attempts"`
 }
 
+// LoggerConfig mengatur logger aplikasi. Encoding bernilai json atau console.
+// Sampling aktif jika SamplingInitial dan SamplingThereafter lebih dari 0.
 type LoggerConfig struct {
-	Level string `mapstructure:"level"`
+	Level              string `mapstructure:"level" validate:"oneof=debug info warn error dpanic panic fatal"`
+	Encoding           string `mapstructure:"encoding" validate:"oneof=json console"`
+	SamplingInitial    int    `mapstructure:"sampling_initial"`
+	SamplingThereafter int    `mapstructure:"sampling_thereafter"`
+}
+
+// PasswordConfig mengatur algoritma dan parameter hashing password
+type PasswordConfig struct {
+	Algorithm         string `mapstructure:"algorithm" validate:"oneof=argon2id bcrypt"`
+	BcryptCost        int    `mapstructure:"bcrypt_cost"`
+	Argon2Memory      uint32 `mapstructure:"argon2_memory"`
+	Argon2Iterations  uint32 `mapstructure:"argon2_iterations"`
+	Argon2Parallelism uint8  `mapstructure:"argon2_parallelism"`
+	Argon2SaltLength  uint32 `mapstructure:"argon2_salt_length"`
+	Argon2KeyLength   uint32 `mapstructure:"argon2_key_length"`
+}
+
+// AuthConfig mengatur penandatanganan access token dan masa berlaku token.
+// JWTAlgorithm mendukung HS256 (menggunakan JWTSecret) dan EdDSA
+// (menggunakan private key Ed25519 PEM pada JWTPrivateKeyPath).
+type AuthConfig struct {
+	JWTAlgorithm      string `mapstructure:"jwt_algorithm" validate:"oneof=HS256 EdDSA"`
+	JWTSecret         string `mapstructure:"jwt_secret" validate:"required_if=JWTAlgorithm HS256"`
+	JWTPrivateKeyPath string `mapstructure:"jwt_private_key_path" validate:"required_if=JWTAlgorithm EdDSA"`
+	Issuer            string `mapstructure:"issuer" validate:"required"`
+	AccessTokenTTL    int    `mapstructure:"access_token_ttl" validate:"gt=0"`
+	RefreshTokenTTL   int    `mapstructure:"refresh_token_ttl" validate:"gt=0"`
+}
+
+// PaginationConfig mengatur secret untuk menandatangani cursor pagination.
+// Secret harus sama di semua replika.
+type PaginationConfig struct {
+	CursorSecret string `mapstructure:"cursor_secret" validate:"required,min=32"`
+}
+
+// SoftDeleteConfig mengatur penghapusan permanen user yang sudah di-soft
+// delete. RetentionDays 0 menonaktifkan purge; PurgeInterval dalam detik.
+type SoftDeleteConfig struct {
+	RetentionDays int `mapstructure:"retention_days" validate:"gte=0"`
+	PurgeInterval int `mapstructure:"purge_interval"`
+}
+
+// MetricsConfig mengatur endpoint Prometheus. Jika Enabled bernilai false,
+// metrics tidak dicatat dan endpoint Path tidak didaftarkan.
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path" validate:"required_if=Enabled true"`
+}
+
+// TracingConfig mengatur OpenTelemetry tracing. Exporter bernilai none,
+// stdout, atau otlp (gRPC ke Endpoint). SampleRatio antara 0 dan 1 berlaku
+// untuk trace baru; trace dari client mengikuti keputusan sampling induknya.
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter" validate:"oneof=none stdout otlp"`
+	Endpoint    string  `mapstructure:"endpoint" validate:"required_if=Exporter otlp"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio" validate:"gte=0,lte=1"`
+}
+
+// HealthConfig mengatur pengecekan dependency untuk /readyz dan health gRPC.
+// CheckTimeout membatasi setiap pengecekan dan hasilnya di-cache selama
+// CacheTTL (dalam detik, 0 berarti tidak di-cache). Pesan error pengecekan
+// hanya dicatat di log kecuali ExposeErrors diaktifkan.
+type HealthConfig struct {
+	CheckTimeout int  `mapstructure:"check_timeout" validate:"gt=0"`
+	CacheTTL     int  `mapstructure:"cache_ttl" validate:"gte=0"`
+	ExposeErrors bool `mapstructure:"expose_errors"`
+}
+
+// MigrationConfig mengatur migrasi database yang di-embed ke binary. Jika
+// OnStart bernilai true, migrasi dijalankan sebelum server start (sama seperti
+// flag --migrate-on-start). LockTimeout (dalam detik) membatasi waktu menunggu
+// advisory lock yang dipegang replika lain.
+type MigrationConfig struct {
+	OnStart     bool `mapstructure:"on_start"`
+	LockTimeout int  `mapstructure:"lock_timeout" validate:"gte=0"`
 }
 
 var cfg *Config
 
-func Init() (*Config, error) {
-	viper.SetConfigName(".env")
-	viper.SetConfigType("env")
-	viper.AddConfigPath(".")
-	viper.AutomaticEnv()
+// setDefaults mengisi nilai default, lapisan konfigurasi paling bawah
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("app.name", "boilerplate-go")
+	v.SetDefault("app.env", EnvDevelopment)
 
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("error reading config file: %w", err)
-	}
+	v.SetDefault("server.port", "8080")
+	v.SetDefault("server.read_timeout", 60)
+	v.SetDefault("server.write_timeout", 60)
+	v.SetDefault("server.shutdown_timeout", 30)
+	v.SetDefault("server.shutdown_drain_delay", 5)
 
-	cfg = &Config{}
-	if err := viper.Unmarshal(cfg); err != nil {
-		return nil, fmt.Errorf("error unmarshaling config: %w", err)
-	}
+	v.SetDefault("grpc.port", "9090")
+	v.SetDefault("grpc.default_timeout", 30)
+	v.SetDefault("grpc.max_timeout", 120)
+	v.SetDefault("grpc.reflection", false)
+	v.SetDefault("grpc.reflection_public", false)
+	v.SetDefault("grpc.health_check_interval", 10)
+
+	v.SetDefault("database.driver", "postgres")
+	v.SetDefault("database.port", "5432")
+	v.SetDefault("database.sslmode", "disable")
+	v.SetDefault("database.max_open_conns", 25)
+	v.SetDefault("database.max_idle_conns", 25)
+	v.SetDefault("database.conn_max_lifetime", 300)
+	v.SetDefault("database.conn_max_idle_time", 60)
+	v.SetDefault("database.connect_timeout", 5)
+	v.SetDefault("database.statement_timeout", 30)
+	v.SetDefault("database.connect_attempts", 5)
+
+	v.SetDefault("logger.level", "info")
+	v.SetDefault("logger.encoding", "json")
+
+	v.SetDefault("password.algorithm", "argon2id")
+	v.SetDefault("password.bcrypt_cost", 12)
+	v.SetDefault("password.argon2_memory", 64*1024)
+	v.SetDefault("password.argon2_iterations", 3)
+	v.SetDefault("password.argon2_parallelism", 2)
+	v.SetDefault("password.argon2_salt_length", 16)
+	v.SetDefault("password.argon2_key_length", 32)
+
+	v.SetDefault("auth.jwt_algorithm", "HS256")
+	v.SetDefault("auth.issuer", "boilerplate-go")
+	v.SetDefault("auth.access_token_ttl", 900)
+	v.SetDefault("auth.refresh_token_ttl", 7*24*3600)
+
+	v.SetDefault("soft_delete.retention_days", 30)
+	v.SetDefault("soft_delete.purge_interval", 3600)
+
+	v.SetDefault("metrics.enabled", true)
+	v.SetDefault("metrics.path", "/metrics")
 
-	return cfg, nil
+	v.SetDefault("tracing.exporter", "none")
+	v.SetDefault("tracing.endpoint", "localhost:4317")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	v.SetDefault("health.check_timeout", 2)
+	v.SetDefault("health.cache_ttl", 1)
+	v.SetDefault("health.expose_errors", false)
+
+	v.SetDefault("migration.on_start", false)
+	v.SetDefault("migration.lock_timeout", 60)
 }
 
+// GetConfig mengembalikan konfigurasi yang terakhir dimuat oleh Load
 func GetConfig() *Config {
 	return cfg
 }
+
+// Settings mengembalikan konfigurasi sebagai map bertingkat dengan key sesuai
+// tag mapstructure, misalnya untuk perintah "config print". Password dan
+// secret yang terisi diganti dengan [REDACTED].
+func (c *Config) Settings() map[string]any {
+	return settingsOf(reflect.ValueOf(*c))
+}
+
+func settingsOf(v reflect.Value) map[string]any {
+	settings := make(map[string]any, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		value := v.Field(i)
+		switch {
+		case value.Kind() == reflect.Struct:
+			settings[key] = settingsOf(value)
+		case isSecretKey(key) && !value.IsZero():
+			settings[key] = redacted
+		default:
+			settings[key] = value.Interface()
+		}
+	}
+	return settings
+}
+
+func isSecretKey(key string) bool {
+	return key == "password" || strings.Contains(key, "secret")
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Settings(t *testing.T) {
+	cfg := &Config{
+		App:        AppConfig{Name: "boilerplate-go"},
+		Database:   DatabaseConfig{Host: "localhost", Password: "postgres"},
+		Password:   PasswordConfig{Algorithm: "argon2id"},
+		Auth:       AuthConfig{JWTSecret: "secret", JWTPrivateKeyPath: "/keys/jwt.pem"},
+		Pagination: PaginationConfig{},
+	}
+
+	settings := cfg.Settings()
+	assert.Equal(t, "boilerplate-go", settings["app"].(map[string]any)["name"])
+
+	database := settings["database"].(map[string]any)
+	assert.Equal(t, "localhost", database["host"])
+	assert.Equal(t, "[REDACTED]", database["password"])
+
+	auth := settings["auth"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", auth["jwt_secret"])
+	assert.Equal(t, "/keys/jwt.pem", auth["jwt_private_key_path"])
+
+	// Section password bukan secret, dan secret kosong tetap ditampilkan kosong
+	assert.Equal(t, "argon2id", settings["password"].(map[string]any)["algorithm"])
+	assert.Equal(t, "", settings["pagination"].(map[string]any)["cursor_secret"])
+}
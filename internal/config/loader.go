@@ -0,0 +1,401 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix adalah prefix environment variable. Key database.host dibaca
+	// dari BOILERPLATE_DATABASE_HOST.
+	EnvPrefix = "BOILERPLATE"
+
+	// PathEnv adalah environment variable berisi path file konfigurasi
+	PathEnv = "CONFIG_PATH"
+)
+
+// defaultPaths dicari berurutan di working directory jika path file
+// konfigurasi tidak diberikan
+var defaultPaths = []string{".env", "config.yaml", "config.yml", "config.toml"}
+
+// legacyEnvNames memetakan nama variabel .env sebelum penamaan mengikuti key
+// konfigurasi ke key barunya. Nama lama masih dibaca dengan peringatan.
+var legacyEnvNames = map[string]string{
+	"DB_DRIVER":               "database.driver",
+	"DB_HOST":                 "database.host",
+	"DB_PORT":                 "database.port",
+	"DB_USERNAME":             "database.username",
+	"DB_PASSWORD":             "database.password",
+	"DB_NAME":                 "database.dbname",
+	"DB_SSL_MODE":             "database.sslmode",
+	"DB_MAX_OPEN_CONNS":       "database.max_open_conns",
+	"DB_MAX_IDLE_CONNS":       "database.max_idle_conns",
+	"DB_CONN_MAX_LIFETIME":    "database.conn_max_lifetime",
+	"DB_CONN_MAX_IDLE_TIME":   "database.conn_max_idle_time",
+	"DB_CONNECT_TIMEOUT":      "database.connect_timeout",
+	"DB_STATEMENT_TIMEOUT":    "database.statement_timeout",
+	"DB_CONNECT_ATTEMPTS":     "database.connect_attempts",
+	"LOG_LEVEL":               "logger.level",
+	"LOG_ENCODING":            "logger.encoding",
+	"LOG_SAMPLING_INITIAL":    "logger.sampling_initial",
+	"LOG_SAMPLING_THEREAFTER": "logger.sampling_thereafter",
+}
+
+// Options mengatur sumber konfigurasi untuk Load
+type Options struct {
+	// Path adalah file konfigurasi (.env, .yaml, .yml, .toml, atau .json).
+	// Kosong berarti memakai CONFIG_PATH, lalu file default di working
+	// directory. File yang diberikan secara eksplisit wajib ada.
+	Path string
+	// Overrides berisi nilai dari flag dengan format key=value, misalnya
+	// server.port=8081
+	Overrides []string
+}
+
+// Load memuat konfigurasi berlapis. Dari prioritas terendah:
+//
+//  1. nilai default
+//  2. file konfigurasi, lalu file profile untuk app.env di direktori yang
+//     sama (config.production.yaml atau .env.production)
+//  3. environment variable dengan prefix EnvPrefix
+//  4. override dari flag
+//
+// Konfigurasi yang tidak valid dikembalikan sebagai error yang menyebutkan
+// setiap key yang salah.
+func Load(opts Options) (*Config, error) {
+	loaded, err := Read(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := loaded.Validate(); err != nil {
+		return nil, err
+	}
+
+	cfg = loaded
+	return cfg, nil
+}
+
+// Read memuat konfigurasi berlapis seperti Load tanpa validasi dan tanpa
+// mengganti konfigurasi global, misalnya untuk menampilkan konfigurasi yang
+// belum lengkap. Key yang tidak dikenal tetap dikembalikan sebagai error.
+func Read(opts Options) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	keys := Keys()
+	for _, key := range keys {
+		if err := v.BindEnv(key, EnvName(key)); err != nil {
+			return nil, fmt.Errorf("error binding env for %s: %w", key, err)
+		}
+	}
+
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	for _, override := range opts.Overrides {
+		key, value, ok := strings.Cut(override, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || !known[key] {
+			return nil, fmt.Errorf("invalid config override %q: expected <key>=<value> with a known key", override)
+		}
+		v.Set(key, value)
+	}
+
+	path, err := resolvePath(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources, warnings []string
+	if path != "" {
+		fileWarnings, err := mergeFile(v, path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, path)
+		warnings = append(warnings, fileWarnings...)
+
+		// Profile dipilih setelah file utama, environment variable dan flag
+		// dibaca sehingga app.env dapat diatur dari lapisan mana pun
+		profile := profilePath(path, v.GetString("app.env"))
+		if _, err := os.Stat(profile); err == nil {
+			fileWarnings, err := mergeFile(v, profile)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, profile)
+			warnings = append(warnings, fileWarnings...)
+		}
+	}
+
+	loaded := &Config{Sources: sources, Warnings: warnings}
+	if err := v.UnmarshalExact(loaded); err != nil {
+		return nil, fmt.Errorf("error unmarshaling config: %w", err)
+	}
+	return loaded, nil
+}
+
+// Init memuat konfigurasi dengan Options default
+func Init() (*Config, error) {
+	return Load(Options{})
+}
+
+// Keys mengembalikan semua key konfigurasi, misalnya database.host, sesuai
+// tag mapstructure pada Config
+func Keys() []string {
+	var keys []string
+	var walk func(prefix string, t reflect.Type)
+	walk = func(prefix string, t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			name := t.Field(i).Tag.Get("mapstructure")
+			if name == "" || name == "-" {
+				continue
+			}
+			if t.Field(i).Type.Kind() == reflect.Struct {
+				walk(prefix+name+".", t.Field(i).Type)
+				continue
+			}
+			keys = append(keys, prefix+name)
+		}
+	}
+	walk("", reflect.TypeOf(Config{}))
+	sort.Strings(keys)
+	return keys
+}
+
+// EnvName mengembalikan nama environment variable untuk key konfigurasi
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// resolvePath menentukan file konfigurasi. File default yang tidak ada
+// diabaikan sehingga aplikasi dapat dikonfigurasi hanya dengan
+// environment variable.
+func resolvePath(path string) (string, error) {
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("error reading config file: %w", err)
+		}
+		return path, nil
+	}
+
+	for _, candidate := range defaultPaths {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// fileType menentukan format file dari nama file. File yang diawali .env
+// (.env, .env.production) dibaca sebagai dotenv.
+func fileType(path string) (string, error) {
+	base := filepath.Base(path)
+	if base == ".env" || strings.HasPrefix(base, ".env.") || filepath.Ext(base) == ".env" {
+		return "env", nil
+	}
+	switch ext := strings.TrimPrefix(filepath.Ext(base), "."); ext {
+	case "yaml", "yml", "toml", "json":
+		return ext, nil
+	default:
+		return "", fmt.Errorf("unsupported config file %s: use .env, .yaml, .yml, .toml or .json", path)
+	}
+}
+
+// profilePath mengembalikan path file profile untuk env di samping path
+func profilePath(path, env string) string {
+	if env == "" {
+		return ""
+	}
+	base := filepath.Base(path)
+	if strings.HasPrefix(base, ".env") {
+		return path + "." + env
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// mergeFile menggabungkan file konfigurasi ke v dan mengembalikan peringatan
+// untuk variabel .env yang sudah diganti namanya atau tidak dikenal
+func mergeFile(v *viper.Viper, path string) ([]string, error) {
+	typ, err := fileType(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ == "env" {
+		values, warnings, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return warnings, v.MergeConfigMap(values)
+	}
+
+	v.SetConfigFile(path)
+	v.SetConfigType(typ)
+	if err := v.MergeInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+	return nil, nil
+}
+
+// readEnvFile membaca file dotenv dan memetakan nama environment variable
+// (dengan atau tanpa EnvPrefix, misalnya DATABASE_HOST) ke key bertingkat.
+// Nama lama pada legacyEnvNames tetap dibaca jika nama barunya tidak ada.
+// Variabel lain diabaikan karena file .env sering dipakai bersama tool lain
+// seperti docker compose, tetapi variabel berawalan nama section (APP_,
+// DATABASE_, ...) yang tidak dikenal dilaporkan sebagai peringatan karena
+// kemungkinan besar salah ketik.
+func readEnvFile(path string) (map[string]any, []string, error) {
+	file := viper.New()
+	file.SetConfigFile(path)
+	file.SetConfigType("env")
+	if err := file.ReadInConfig(); err != nil {
+		return nil, nil, fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+
+	names := make(map[string]string)
+	sections := map[string]bool{strings.ToLower(EnvPrefix): true}
+	for _, key := range Keys() {
+		name := strings.ToLower(EnvName(key))
+		names[name] = key
+		names[strings.TrimPrefix(name, strings.ToLower(EnvPrefix)+"_")] = key
+		section, _, _ := strings.Cut(key, ".")
+		sections[section] = true
+	}
+
+	settings := file.AllSettings()
+	fileNames := make([]string, 0, len(settings))
+	for name := range settings {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	values := make(map[string]any)
+	set := func(key string, value any) {
+		section, field, _ := strings.Cut(key, ".")
+		if _, ok := values[section]; !ok {
+			values[section] = make(map[string]any)
+		}
+		values[section].(map[string]any)[field] = value
+	}
+
+	var warnings, legacy []string
+	for _, name := range fileNames {
+		if key, ok := names[name]; ok {
+			set(key, settings[name])
+			continue
+		}
+		upper := strings.ToUpper(name)
+		if _, ok := legacyEnvNames[upper]; ok {
+			legacy = append(legacy, upper)
+			continue
+		}
+		if hasSectionPrefix(name, sections) {
+			warnings = append(warnings, fmt.Sprintf("%s: unknown config variable %s", path, upper))
+		}
+	}
+
+	for _, name := range legacy {
+		key := legacyEnvNames[name]
+		newName := strings.TrimPrefix(EnvName(key), EnvPrefix+"_")
+		warnings = append(warnings, fmt.Sprintf("%s: %s is deprecated, rename it to %s", path, name, newName))
+		section, field, _ := strings.Cut(key, ".")
+		if existing, ok := values[section].(map[string]any); ok {
+			if _, ok := existing[field]; ok {
+				continue
+			}
+		}
+		set(key, settings[strings.ToLower(name)])
+	}
+	return values, warnings, nil
+}
+
+// hasSectionPrefix bernilai true jika name diawali nama section konfigurasi,
+// misalnya database_hots
+func hasSectionPrefix(name string, sections map[string]bool) bool {
+	for section := range sections {
+		if strings.HasPrefix(name, section+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate memeriksa field wajib dan rentang nilai sesuai tag validate
+func (c *Config) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("mapstructure")
+	})
+
+	err := validate.Struct(c)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("error validating config: %w", err)
+	}
+
+	problems := make([]string, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		key := strings.TrimPrefix(fe.Namespace(), "Config.")
+		problems = append(problems, fmt.Sprintf("%s %s (%s)", key, validationMessage(fe), EnvName(key)))
+	}
+	return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min":
+		return fmt.Sprintf("must be at least %s characters long", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "ltfield":
+		return fmt.Sprintf("must be less than %s", siblingKey(fe))
+	default:
+		return fmt.Sprintf("failed on the %q rule", fe.Tag())
+	}
+}
+
+// siblingKey mengubah nama field Go pada parameter tag seperti ltfield menjadi
+// key konfigurasi di section yang sama, misalnya server.shutdown_timeout
+func siblingKey(fe validator.FieldError) string {
+	key := strings.TrimPrefix(fe.Namespace(), "Config.")
+	section := key[:strings.LastIndex(key, ".")+1]
+
+	var field strings.Builder
+	for i, r := range fe.Param() {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				field.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		field.WriteRune(r)
+	}
+	return section + field.String()
+}
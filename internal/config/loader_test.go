@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requiredYAML berisi field wajib yang tidak memiliki default
+const requiredYAML = `
+database:
+  host: file-host
+  username: postgres
+  dbname: boilerplate
+auth:
+  jwt_secret: test-secret-that-is-at-least-32-bytes
+pagination:
+  cursor_secret: test-cursor-secret-that-is-at-least-32-bytes
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Layers(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.yaml", requiredYAML+`
+server:
+  port: "8000"
+`)
+	profile := writeFile(t, dir, "config.production.yaml", `
+database:
+  host: prod-host
+logger:
+  encoding: console
+`)
+
+	// app.env dari environment variable menentukan profile yang dibaca
+	t.Setenv("BOILERPLATE_APP_ENV", EnvProduction)
+	t.Setenv("BOILERPLATE_DATABASE_PASSWORD", "from-env")
+	t.Setenv("BOILERPLATE_LOGGER_ENCODING", "json")
+
+	cfg, err := Load(Options{Path: path, Overrides: []string{"server.port=9999"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{path, profile}, cfg.Sources)
+	assert.Equal(t, EnvProduction, cfg.App.Env)
+	assert.Equal(t, "prod-host", cfg.Database.Host)
+	assert.Equal(t, "from-env", cfg.Database.Password)
+	// Environment variable lebih tinggi dari profile, dan flag paling tinggi
+	assert.Equal(t, "json", cfg.Logger.Encoding)
+	assert.Equal(t, "9999", cfg.Server.Port)
+	// Default tetap berlaku untuk key yang tidak diatur
+	assert.Equal(t, 30, cfg.Server.ShutdownTimeout)
+	assert.Same(t, cfg, GetConfig())
+}
+
+func TestLoad_EnvFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, ".env", `
+APP_ENV=staging
+DATABASE_HOST=localhost
+BOILERPLATE_DATABASE_USERNAME=postgres
+DATABASE_DBNAME=boilerplate
+AUTH_JWT_SECRET=test-secret-that-is-at-least-32-bytes
+PAGINATION_CURSOR_SECRET=test-cursor-secret-that-is-at-least-32-bytes
+SOFT_DELETE_RETENTION_DAYS=7
+POSTGRES_PASSWORD=ignored
+`)
+	writeFile(t, dir, ".env.staging", "GRPC_REFLECTION=true\n")
+
+	cfg, err := Load(Options{Path: path})
+	require.NoError(t, err)
+
+	assert.Len(t, cfg.Sources, 2)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, "postgres", cfg.Database.Username)
+	assert.Equal(t, 7, cfg.SoftDelete.RetentionDays)
+	assert.True(t, cfg.GRPC.Reflection)
+	assert.False(t, cfg.GRPC.ReflectionPublic)
+}
+
+func TestLoad_EnvFileLegacyNames(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, ".env", `
+DB_HOST=legacy-host
+DATABASE_HOST=localhost
+DB_USERNAME=postgres
+DB_NAME=boilerplate
+DB_SSL_MODE=require
+LOG_LEVEL=warn
+DATABASE_HOTS=typo
+BOILERPLATE_GRPC_PROT=9091
+AUTH_JWT_SECRET=test-secret-that-is-at-least-32-bytes
+PAGINATION_CURSOR_SECRET=test-cursor-secret-that-is-at-least-32-bytes
+POSTGRES_PASSWORD=ignored
+`)
+
+	cfg, err := Load(Options{Path: path})
+	require.NoError(t, err)
+
+	// Nama baru menang atas nama lama
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, "postgres", cfg.Database.Username)
+	assert.Equal(t, "boilerplate", cfg.Database.DBName)
+	assert.Equal(t, "require", cfg.Database.SSLMode)
+	assert.Equal(t, "warn", cfg.Logger.Level)
+
+	assert.ElementsMatch(t, []string{
+		path + ": unknown config variable BOILERPLATE_GRPC_PROT",
+		path + ": unknown config variable DATABASE_HOTS",
+		path + ": DB_HOST is deprecated, rename it to DATABASE_HOST",
+		path + ": DB_NAME is deprecated, rename it to DATABASE_DBNAME",
+		path + ": DB_SSL_MODE is deprecated, rename it to DATABASE_SSLMODE",
+		path + ": DB_USERNAME is deprecated, rename it to DATABASE_USERNAME",
+		path + ": LOG_LEVEL is deprecated, rename it to LOGGER_LEVEL",
+	}, cfg.Warnings)
+}
+
+func TestLegacyEnvNames(t *testing.T) {
+	known := make(map[string]bool)
+	for _, key := range Keys() {
+		known[key] = true
+	}
+	for name, key := range legacyEnvNames {
+		assert.True(t, known[key], "legacy variable %s maps to unknown key %s", name, key)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(Options{Path: writeFile(t, dir, "empty.yaml", "logger:\n  encoding: xml\n")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.host is required (BOILERPLATE_DATABASE_HOST)")
+	assert.Contains(t, err.Error(), "pagination.cursor_secret is required")
+	assert.Contains(t, err.Error(), "logger.encoding must be one of: json, console")
+
+	_, err = Load(Options{Path: writeFile(t, dir, "drain.yaml", requiredYAML), Overrides: []string{"server.shutdown_drain_delay=30"}})
+	assert.ErrorContains(t, err, "server.shutdown_drain_delay must be less than server.shutdown_timeout (BOILERPLATE_SERVER_SHUTDOWN_DRAIN_DELAY)")
+
+	_, err = Load(Options{Path: writeFile(t, dir, "typo.yaml", requiredYAML+"databse:\n  host: x\n")})
+	assert.ErrorContains(t, err, "databse")
+
+	_, err = Load(Options{Path: writeFile(t, dir, "valid.yaml", requiredYAML), Overrides: []string{"server.prot=1"}})
+	assert.ErrorContains(t, err, "invalid config override")
+
+	_, err = Load(Options{Path: filepath.Join(dir, "missing.yaml")})
+	assert.ErrorContains(t, err, "error reading config file")
+
+	_, err = Load(Options{Path: writeFile(t, dir, "config.ini", "")})
+	assert.ErrorContains(t, err, "unsupported config file")
+}
+
+func TestRead_SkipsValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "empty.yaml", "server:\n  port: \"8081\"\n")
+
+	cfg, err := Read(Options{Path: path})
+	require.NoError(t, err)
+	assert.Equal(t, "8081", cfg.Server.Port)
+	assert.ErrorContains(t, cfg.Validate(), "database.host is required")
+
+	// Key yang tidak dikenal tetap gagal
+	_, err = Read(Options{Path: writeFile(t, dir, "typo.yaml", "databse:\n  host: x\n")})
+	assert.ErrorContains(t, err, "databse")
+}
+
+// TestEnvExample memastikan setiap variabel di .env.example dipetakan ke key
+// konfigurasi
+func TestEnvExample(t *testing.T) {
+	file, err := os.Open("../../.env.example")
+	require.NoError(t, err)
+	defer file.Close()
+
+	names := make(map[string]bool)
+	for _, key := range Keys() {
+		names[strings.TrimPrefix(EnvName(key), EnvPrefix+"_")] = true
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, _, _ := strings.Cut(line, "=")
+		assert.True(t, names[name], "unknown config variable %s", name)
+	}
+	require.NoError(t, scanner.Err())
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+)
+
+// SeedInput adalah input perintah "seed". Admin hanya dibuat jika
+// AdminEmail diisi; DemoUsers menambahkan user demo<N>@example.com untuk
+// development.
+type SeedInput struct {
+	AdminEmail    string
+	AdminName     string
+	AdminPassword string
+	DemoUsers     int
+}
+
+// Seeder mengisi data awal. Seed bersifat idempotent: user yang emailnya
+// sudah terdaftar dilewati sehingga aman dijalankan berulang kali.
+type Seeder struct {
+	users *UserCommand
+	out   io.Writer
+}
+
+// NewSeeder membuat instance baru dari Seeder
+func NewSeeder(userUseCase usecase_interface.UserUseCase, out io.Writer) *Seeder {
+	return &Seeder{
+		users: NewUserCommand(userUseCase, out),
+		out:   out,
+	}
+}
+
+// Seed membuat admin dan user demo yang belum ada
+func (s *Seeder) Seed(ctx context.Context, input SeedInput) error {
+	if input.AdminEmail != "" {
+		name := input.AdminName
+		if name == "" {
+			name = "Administrator"
+		}
+		if err := s.createIfMissing(ctx, CreateUserInput{
+			Email:    input.AdminEmail,
+			Name:     name,
+			Password: input.AdminPassword,
+			Role:     entity.RoleAdmin,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for i := 1; i <= input.DemoUsers; i++ {
+		if err := s.createIfMissing(ctx, CreateUserInput{
+			Email: fmt.Sprintf("demo%d@example.com", i),
+			Name:  fmt.Sprintf("Demo User %d", i),
+			Role:  entity.RoleUser,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Seeder) createIfMissing(ctx context.Context, input CreateUserInput) error {
+	taken, err := s.users.userUseCase.IsEmailTaken(ctx, input.Email, "")
+	if err != nil {
+		return fmt.Errorf("error checking email: %w", err)
+	}
+	if taken {
+		fmt.Fprintf(s.out, "skipped %s: already exists\n", input.Email)
+		return nil
+	}
+	_, err = s.users.Create(ctx, input)
+	return err
+}
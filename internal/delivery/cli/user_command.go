@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/validation"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+)
+
+const (
+	// generatedPasswordLength adalah panjang password acak jika operator
+	// tidak memberikan password
+	generatedPasswordLength = 20
+	passwordAlphabet        = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// CreateUserInput adalah input perintah "users create". Password kosong
+// berarti password dibuat acak dan ditampilkan sekali. Aturan validasi sama
+// dengan request POST /users.
+type CreateUserInput struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72,password"`
+	Role     string `json:"role" validate:"required,oneof=admin user"`
+}
+
+// resetPasswordInput adalah input perintah "users reset-password"
+type resetPasswordInput struct {
+	Password string `json:"password" validate:"required,min=8,max=72,password"`
+}
+
+// ListUsersInput adalah input perintah "users list"
+type ListUsersInput struct {
+	Search         string
+	Limit          int
+	IncludeDeleted bool
+}
+
+// UserCommand menjalankan administrasi user dari command line dengan usecase
+// yang sama seperti HTTP dan gRPC, sehingga hashing password dan aturan
+// domain lainnya tetap berlaku. Hasil ditulis ke out untuk operator.
+type UserCommand struct {
+	userUseCase usecase_interface.UserUseCase
+	out         io.Writer
+	validator   *validation.Validator
+}
+
+// NewUserCommand membuat instance baru dari UserCommand
+func NewUserCommand(userUseCase usecase_interface.UserUseCase, out io.Writer) *UserCommand {
+	return &UserCommand{
+		userUseCase: userUseCase,
+		out:         out,
+		validator:   validation.New(userUseCase),
+	}
+}
+
+// Create membuat user baru
+func (c *UserCommand) Create(ctx context.Context, input CreateUserInput) (*entity.User, error) {
+	if input.Role == "" {
+		input.Role = entity.DefaultRole
+	}
+
+	generated := input.Password == ""
+	if generated {
+		password, err := generatePassword()
+		if err != nil {
+			return nil, err
+		}
+		input.Password = password
+	}
+
+	if err := c.validator.User(ctx, &input, input.Email, ""); err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{
+		Email:    input.Email,
+		Name:     input.Name,
+		Password: input.Password,
+		Role:     input.Role,
+	}
+	if err := c.userUseCase.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	fmt.Fprintf(c.out, "created user %s (%s, role %s)\n", user.ID, user.Email, user.Role)
+	if generated {
+		fmt.Fprintf(c.out, "generated password: %s\n", input.Password)
+	}
+	return user, nil
+}
+
+// List menampilkan user sebagai tabel, diurutkan dari yang terbaru
+func (c *UserCommand) List(ctx context.Context, input ListUsersInput) error {
+	users, err := c.userUseCase.ListUsers(ctx, repoInterface.ListQuery{
+		Search:         input.Search,
+		Limit:          input.Limit,
+		IncludeDeleted: input.IncludeDeleted,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing users: %w", err)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tNAME\tROLE\tCREATED AT\tDELETED AT")
+	for _, user := range users {
+		deletedAt := "-"
+		if user.DeletedAt != nil {
+			deletedAt = user.DeletedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			user.ID, user.Email, user.Name, user.Role, user.CreatedAt.Format(time.RFC3339), deletedAt)
+	}
+	return w.Flush()
+}
+
+// Delete melakukan soft delete pada user. User dapat dipulihkan melalui API
+// sampai di-purge setelah masa retensi.
+func (c *UserCommand) Delete(ctx context.Context, id string) error {
+	if err := c.userUseCase.DeleteUser(ctx, id); err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	fmt.Fprintf(c.out, "deleted user %s\n", id)
+	return nil
+}
+
+// ResetPassword mengganti password user. Password kosong berarti password
+// dibuat acak dan ditampilkan sekali.
+func (c *UserCommand) ResetPassword(ctx context.Context, id, password string) error {
+	generated := password == ""
+	if generated {
+		var err error
+		if password, err = generatePassword(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.validator.Struct(&resetPasswordInput{Password: password}); err != nil {
+		return err
+	}
+
+	if _, err := c.userUseCase.PatchUser(ctx, id, entity.UserPatch{Password: &password}); err != nil {
+		return fmt.Errorf("error resetting password: %w", err)
+	}
+
+	fmt.Fprintf(c.out, "password reset for user %s\n", id)
+	if generated {
+		fmt.Fprintf(c.out, "generated password: %s\n", password)
+	}
+	return nil
+}
+
+// generatePassword membuat password acak yang memenuhi aturan kekuatan
+// password
+func generatePassword() (string, error) {
+	max := big.NewInt(int64(len(passwordAlphabet)))
+	for {
+		b := make([]byte, generatedPasswordLength)
+		for i := range b {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", fmt.Errorf("error generating password: %w", err)
+			}
+			b[i] = passwordAlphabet[n.Int64()]
+		}
+		if password := string(b); entity.IsStrongPassword(password) {
+			return password, nil
+		}
+	}
+}
+
+// FormatError menyusun pesan error untuk operator, termasuk daftar field
+// yang gagal validasi
+func FormatError(err error) string {
+	appErr := apperror.From(err)
+	if appErr.Kind == apperror.KindInternal {
+		return err.Error()
+	}
+
+	fields, _ := appErr.Details["fields"].([]apperror.FieldError)
+	if len(fields) == 0 {
+		return appErr.Message
+	}
+	lines := []string{appErr.Message + ":"}
+	for _, field := range fields {
+		lines = append(lines, fmt.Sprintf("  %s: %s", field.Field, field.Message))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserUseCase adalah mock untuk UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) PatchUser(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) RestoreUser(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsers(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterface.Page[entity.User]), args.Error(1)
+}
+
+func (m *MockUserUseCase) VerifyCredentials(ctx context.Context, email, password string) (*entity.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	args := m.Called(ctx, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestUserCommand_Create(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	var out bytes.Buffer
+	cmd := NewUserCommand(mockUseCase, &out)
+	ctx := context.Background()
+
+	mockUseCase.On("IsEmailTaken", ctx, "admin@example.com", "").Return(false, nil)
+	mockUseCase.On("CreateUser", ctx, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Email == "admin@example.com" && u.Role == entity.RoleAdmin && entity.IsStrongPassword(u.Password)
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*entity.User).ID = "new-id"
+	}).Return(nil)
+
+	user, err := cmd.Create(ctx, CreateUserInput{Email: "admin@example.com", Name: "Admin", Role: entity.RoleAdmin})
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", user.ID)
+	assert.Contains(t, out.String(), "created user new-id (admin@example.com, role admin)")
+	assert.Contains(t, out.String(), "generated password: ")
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserCommand_Create_Invalid(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	cmd := NewUserCommand(mockUseCase, new(bytes.Buffer))
+	ctx := context.Background()
+
+	mockUseCase.On("IsEmailTaken", ctx, "taken@example.com", "").Return(true, nil)
+
+	_, err := cmd.Create(ctx, CreateUserInput{Email: "not-an-email", Name: "", Password: "weakpassword", Role: "owner"})
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(t, "validation failed:\n"+
+		"  email: must be a valid email address\n"+
+		"  name: is required\n"+
+		"  password: must contain at least one upper case letter, one lower case letter and one digit\n"+
+		"  role: must be one of: admin, user",
+		FormatError(err))
+
+	// Pesan email mengikuti aturan yang gagal, sama seperti pada HTTP
+	_, err = cmd.Create(ctx, CreateUserInput{Name: "John", Password: "Str0ngPassword"})
+	assert.Equal(t, "validation failed:\n  email: is required", FormatError(err))
+
+	_, err = cmd.Create(ctx, CreateUserInput{Email: "taken@example.com", Name: "Taken", Password: "Str0ngPassword"})
+	assert.Contains(t, FormatError(err), "email: email is already registered")
+
+	mockUseCase.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestUserCommand_List(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	var out bytes.Buffer
+	cmd := NewUserCommand(mockUseCase, &out)
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockUseCase.On("ListUsers", mock.Anything, repoInterface.ListQuery{Search: "john", Limit: 10}).
+		Return([]*entity.User{{ID: "user-1", Email: "john@example.com", Name: "John", Role: "user", CreatedAt: createdAt}}, nil)
+
+	require.NoError(t, cmd.List(context.Background(), ListUsersInput{Search: "john", Limit: 10}))
+	assert.Contains(t, out.String(), "ID      EMAIL")
+	assert.Contains(t, out.String(), "user-1  john@example.com  John  user  2024-01-02T03:04:05Z  -")
+}
+
+func TestUserCommand_Delete(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	var out bytes.Buffer
+	cmd := NewUserCommand(mockUseCase, &out)
+
+	mockUseCase.On("DeleteUser", mock.Anything, "user-1").Return(nil)
+	mockUseCase.On("DeleteUser", mock.Anything, "unknown-id").Return(entity.ErrUserNotFound)
+
+	require.NoError(t, cmd.Delete(context.Background(), "user-1"))
+	assert.Equal(t, "deleted user user-1\n", out.String())
+
+	err := cmd.Delete(context.Background(), "unknown-id")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func TestUserCommand_ResetPassword(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	var out bytes.Buffer
+	cmd := NewUserCommand(mockUseCase, &out)
+	ctx := context.Background()
+
+	password := "N3wPassword"
+	mockUseCase.On("PatchUser", ctx, "user-1", entity.UserPatch{Password: &password}).Return(&entity.User{ID: "user-1"}, nil)
+
+	require.NoError(t, cmd.ResetPassword(ctx, "user-1", password))
+	assert.Equal(t, "password reset for user user-1\n", out.String())
+
+	err := cmd.ResetPassword(ctx, "user-1", "short")
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+
+	mockUseCase.AssertNumberOfCalls(t, "PatchUser", 1)
+}
+
+func TestSeeder_Seed(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	var out bytes.Buffer
+	seeder := NewSeeder(mockUseCase, &out)
+	ctx := context.Background()
+
+	mockUseCase.On("IsEmailTaken", ctx, "admin@example.com", "").Return(true, nil)
+	mockUseCase.On("IsEmailTaken", ctx, "demo1@example.com", "").Return(false, nil)
+	mockUseCase.On("CreateUser", ctx, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Email == "demo1@example.com" && u.Role == entity.RoleUser
+	})).Return(nil)
+
+	require.NoError(t, seeder.Seed(ctx, SeedInput{AdminEmail: "admin@example.com", DemoUsers: 1}))
+	assert.Contains(t, out.String(), "skipped admin@example.com: already exists")
+	assert.Contains(t, out.String(), "(demo1@example.com, role user)")
+	mockUseCase.AssertExpectations(t)
+
+	mockUseCase.On("IsEmailTaken", ctx, "demo2@example.com", "").Return(false, errors.New("connection refused"))
+	err := seeder.Seed(ctx, SeedInput{DemoUsers: 2})
+	assert.EqualError(t, err, "error checking email: connection refused")
+}
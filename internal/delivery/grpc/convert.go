@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoUser(user *entity.User) *pb.User {
+	out := &pb.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Version:   int64(user.Version),
+	}
+	if user.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+	return out
+}
+
+func toProtoUsers(users []*entity.User) []*pb.User {
+	out := make([]*pb.User, 0, len(users))
+	for _, user := range users {
+		out = append(out, toProtoUser(user))
+	}
+	return out
+}
+
+// toFilters mengubah filter dari request. Nama field dan operator divalidasi
+// oleh repository terhadap whitelist entitas.
+func toFilters(filters []*pb.Filter) []repoInterface.Filter {
+	out := make([]repoInterface.Filter, 0, len(filters))
+	for _, f := range filters {
+		out = append(out, repoInterface.Filter{
+			Field:    f.GetField(),
+			Operator: repoInterface.Operator(f.GetOperator()),
+			Value:    f.GetValue(),
+		})
+	}
+	return out
+}
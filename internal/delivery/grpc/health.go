@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	apphealth "github.com/sekolahmu/boilerplate-go/pkg/health"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// HealthMethods adalah method health checking. Method ini dipanggil oleh
+// probe Kubernetes tanpa token, dan Watch adalah stream jangka panjang yang
+// tidak boleh diberi deadline.
+var HealthMethods = []string{
+	healthpb.Health_Check_FullMethodName,
+	healthpb.Health_Watch_FullMethodName,
+}
+
+// ReflectionMethods adalah method server reflection. Reflection membuka
+// seluruh skema service sehingga tetap membutuhkan token kecuali
+// GRPCConfig.ReflectionPublic diaktifkan.
+var ReflectionMethods = []string{
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+}
+
+// AllowedMethods mengembalikan daftar baru method yang boleh dipanggil tanpa
+// token: PublicMethods, HealthMethods dan ReflectionMethods jika reflection
+// aktif dan ReflectionPublic bernilai true.
+func AllowedMethods(cfg config.GRPCConfig) []string {
+	methods := make([]string, 0, len(PublicMethods)+len(HealthMethods)+len(ReflectionMethods))
+	methods = append(methods, PublicMethods...)
+	methods = append(methods, HealthMethods...)
+	if cfg.Reflection && cfg.ReflectionPublic {
+		methods = append(methods, ReflectionMethods...)
+	}
+	return methods
+}
+
+// StreamMethods mengembalikan daftar baru method stream jangka panjang yang
+// tidak diberi default deadline.
+func StreamMethods() []string {
+	methods := make([]string, 0, len(HealthMethods)+len(ReflectionMethods))
+	methods = append(methods, HealthMethods...)
+	return append(methods, ReflectionMethods...)
+}
+
+// HealthChecker memperbarui status grpc.health.v1.Health berdasarkan hasil
+// health registry secara berkala, sehingga probe HTTP dan gRPC memakai
+// pengecekan dependency yang sama. Status berlaku untuk server secara
+// keseluruhan ("") dan untuk setiap service yang didaftarkan.
+type HealthChecker struct {
+	server   *health.Server
+	registry *apphealth.Registry
+	services []string
+	interval time.Duration
+
+	mu      sync.Mutex
+	serving bool
+}
+
+// NewHealthChecker membuat instance baru dari HealthChecker. Status awal
+// adalah NOT_SERVING sampai pengecekan pertama berhasil.
+func NewHealthChecker(registry *apphealth.Registry, interval time.Duration) *HealthChecker {
+	h := &HealthChecker{
+		server:   health.NewServer(),
+		registry: registry,
+		services: []string{"", pb.UserService_ServiceDesc.ServiceName},
+		interval: interval,
+	}
+	h.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+// Register mendaftarkan grpc.health.v1.Health ke server gRPC
+func (h *HealthChecker) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, h.server)
+}
+
+// Run menjalankan pengecekan sekali saat start lalu setiap interval sampai
+// ctx dibatalkan
+func (h *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check memeriksa semua dependency dan memperbarui status serving
+func (h *HealthChecker) Check(ctx context.Context) bool {
+	report := h.registry.Ready(ctx)
+	for name, result := range report.Checks {
+		if result.Status != apphealth.StatusUp {
+			logger.FromContext(ctx).Warn("health check failed", zap.String("dependency", name), zap.String("error", result.Error))
+		}
+	}
+	serving := report.Healthy()
+
+	h.mu.Lock()
+	changed := serving != h.serving
+	h.serving = serving
+	h.mu.Unlock()
+
+	if changed {
+		if serving {
+			h.setStatus(healthpb.HealthCheckResponse_SERVING)
+		} else {
+			h.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+	}
+	return serving
+}
+
+// Shutdown mengubah semua status menjadi NOT_SERVING dan mengabaikan hasil
+// pengecekan berikutnya, sehingga load balancer berhenti mengirim request
+// sebelum server dihentikan
+func (h *HealthChecker) Shutdown() {
+	h.server.Shutdown()
+}
+
+func (h *HealthChecker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,187 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/interceptor"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	apphealth "github.com/sekolahmu/boilerplate-go/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakePinger gagal selama healthy bernilai false
+type fakePinger struct {
+	healthy atomic.Bool
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	if !p.healthy.Load() {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+// newTestHealthChecker membuat HealthChecker tanpa cache agar setiap Check
+// langsung membaca status dependency
+func newTestHealthChecker(deps map[string]apphealth.Pinger) *HealthChecker {
+	registry := apphealth.NewRegistry(time.Second, 0)
+	for name, dep := range deps {
+		registry.Register(name, apphealth.PingCheck(dep))
+	}
+	return NewHealthChecker(registry, time.Hour)
+}
+
+func setupHealthServer(t *testing.T, checker *HealthChecker, cfg config.GRPCConfig) *grpc.ClientConn {
+	tokenManager, err := auth.NewTokenManager(config.AuthConfig{
+		JWTAlgorithm:    auth.AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+	require.NoError(t, err)
+
+	cfg.DefaultTimeout = 1
+	chain, err := interceptor.NewChain(cfg, zap.NewNop(), tokenManager, AllowedMethods(cfg)...)
+	require.NoError(t, err)
+	chain.SkipDeadline(StreamMethods()...)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(chain.ServerOptions()...)
+	pb.RegisterUserServiceServer(server, &pb.UnimplementedUserServiceServer{})
+	checker.Register(server)
+	reflection.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestHealthChecker(t *testing.T) {
+	db := &fakePinger{}
+	checker := newTestHealthChecker(map[string]apphealth.Pinger{"database": db})
+	client := healthpb.NewHealthClient(setupHealthServer(t, checker, config.GRPCConfig{}))
+	ctx := context.Background()
+
+	statusOf := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	// Belum ada pengecekan yang berhasil
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(""))
+
+	db.healthy.Store(true)
+	assert.True(t, checker.Check(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, statusOf(pb.UserService_ServiceDesc.ServiceName))
+
+	db.healthy.Store(false)
+	assert.False(t, checker.Check(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(""))
+
+	// Setelah Shutdown status tetap NOT_SERVING walaupun database sehat
+	db.healthy.Store(true)
+	checker.Check(ctx)
+	checker.Shutdown()
+	checker.Check(ctx)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, statusOf(""))
+}
+
+func TestHealthChecker_Watch(t *testing.T) {
+	db := &fakePinger{}
+	db.healthy.Store(true)
+	checker := newTestHealthChecker(map[string]apphealth.Pinger{"database": db})
+	client := healthpb.NewHealthClient(setupHealthServer(t, checker, config.GRPCConfig{}))
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	// Watch tidak terkena default deadline dari interceptor
+	time.Sleep(1500 * time.Millisecond)
+	checker.Check(context.Background())
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestReflection(t *testing.T) {
+	checker := newTestHealthChecker(nil)
+	conn := setupHealthServer(t, checker, config.GRPCConfig{Reflection: true, ReflectionPublic: true})
+	client := reflectionpb.NewServerReflectionClient(conn)
+
+	stream, err := client.ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, pb.UserService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+}
+
+func TestReflection_RequiresToken(t *testing.T) {
+	checker := newTestHealthChecker(nil)
+	conn := setupHealthServer(t, checker, config.GRPCConfig{Reflection: true})
+	client := reflectionpb.NewServerReflectionClient(conn)
+
+	stream, err := client.ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	_ = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Health check tetap dapat dipanggil tanpa token
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
+
+func TestAllowedMethods(t *testing.T) {
+	methods := AllowedMethods(config.GRPCConfig{Reflection: true})
+	assert.Subset(t, methods, PublicMethods)
+	assert.Subset(t, methods, HealthMethods)
+	for _, method := range ReflectionMethods {
+		assert.NotContains(t, methods, method)
+	}
+
+	assert.Subset(t, AllowedMethods(config.GRPCConfig{Reflection: true, ReflectionPublic: true}), ReflectionMethods)
+	assert.NotContains(t, AllowedMethods(config.GRPCConfig{ReflectionPublic: true}), ReflectionMethods[0])
+
+	// PublicMethods tidak ikut berubah
+	assert.Len(t, PublicMethods, 1)
+}
@@ -0,0 +1,79 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// AuthorizationMetadataKey adalah key metadata yang berisi bearer token
+const AuthorizationMetadataKey = "authorization"
+
+// UnaryAuth memverifikasi bearer token pada metadata "authorization" dan
+// menyimpan principal ke context. Method pada publicMethods tetap dapat
+// dipanggil tanpa token, tetapi token yang tidak valid tetap ditolak.
+func UnaryAuth(tokenManager *auth.TokenManager, publicMethods ...string) grpc.UnaryServerInterceptor {
+	public := methodSet(publicMethods)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, tokenManager, public[info.FullMethod])
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth adalah versi stream dari UnaryAuth
+func StreamAuth(tokenManager *auth.TokenManager, publicMethods ...string) grpc.StreamServerInterceptor {
+	public := methodSet(publicMethods)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), tokenManager, public[info.FullMethod])
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authenticate(ctx context.Context, tokenManager *auth.TokenManager, public bool) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, AuthorizationMetadataKey)
+	if len(values) == 0 {
+		if public {
+			return ctx, nil
+		}
+		return ctx, auth.ErrMissingToken
+	}
+
+	token, err := auth.ExtractBearerToken(values[0])
+	if err != nil {
+		return ctx, err
+	}
+	principal, err := tokenManager.ParseAccessToken(token)
+	if err != nil {
+		return ctx, auth.ErrInvalidToken
+	}
+	ctx = auth.NewContext(ctx, principal)
+	return logger.With(ctx, zap.String("user_id", principal.UserID)), nil
+}
+
+func methodSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		set[method] = true
+	}
+	return set
+}
+
+// serverStream mengganti context milik grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
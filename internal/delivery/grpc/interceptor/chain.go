@@ -0,0 +1,113 @@
+package interceptor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/pkg/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Chain menyusun interceptor server dengan urutan yang sama untuk unary dan
+// stream: recovery, request id, logging, metrics (opsional), konversi error,
+// recovery, deadline, autentikasi lalu validasi.
+//
+// Recovery dipasang dua kali. Recovery paling luar adalah jaring pengaman
+// untuk panic pada request id, logging dan metrics; recovery di dalam
+// konversi error menangkap panic handler sehingga logging dan metrics tetap
+// mencatat request tersebut sebagai Internal. Konversi error berada di dalam
+// logging dan metrics agar keduanya mencatat status code akhir; interceptor
+// di luarnya tidak membuat error sendiri dan hanya meneruskan error yang
+// sudah dikonversi. Validasi berada paling dalam agar request anonim
+// ditolak sebelum isinya diperiksa.
+type Chain struct {
+	logger         *zap.Logger
+	tokenManager   *auth.TokenManager
+	publicMethods  []string
+	noDeadline     []string
+	metrics        *metrics.Metrics
+	defaultTimeout time.Duration
+	maxTimeout     time.Duration
+}
+
+// NewChain membuat Chain dari konfigurasi gRPC. tokenManager wajib diisi
+// karena service tidak boleh berjalan tanpa autentikasi; publicMethods
+// adalah method yang boleh dipanggil tanpa token.
+func NewChain(cfg config.GRPCConfig, logger *zap.Logger, tokenManager *auth.TokenManager, publicMethods ...string) (*Chain, error) {
+	if tokenManager == nil {
+		return nil, errors.New("gRPC interceptor chain requires a token manager")
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &Chain{
+		logger:         logger,
+		tokenManager:   tokenManager,
+		publicMethods:  publicMethods,
+		defaultTimeout: time.Duration(cfg.DefaultTimeout) * time.Second,
+		maxTimeout:     time.Duration(cfg.MaxTimeout) * time.Second,
+	}, nil
+}
+
+// SkipDeadline menandai method yang tidak diberi deadline oleh Chain,
+// misalnya stream jangka panjang seperti grpc.health.v1.Health/Watch
+func (c *Chain) SkipDeadline(methods ...string) *Chain {
+	c.noDeadline = append(c.noDeadline, methods...)
+	return c
+}
+
+// WithMetrics memasang interceptor metrics setelah logging. Tanpa
+// WithMetrics, Chain tidak mencatat metrics.
+func (c *Chain) WithMetrics(m *metrics.Metrics) *Chain {
+	c.metrics = m
+	return c
+}
+
+// Unary mengembalikan interceptor unary sesuai urutan Chain
+func (c *Chain) Unary() []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		UnaryRecovery(c.logger),
+		UnaryRequestID(),
+		UnaryLogging(c.logger),
+	}
+	if c.metrics != nil {
+		interceptors = append(interceptors, UnaryMetrics(c.metrics))
+	}
+	return append(interceptors,
+		UnaryErrors(),
+		UnaryRecovery(c.logger),
+		UnaryDeadline(c.defaultTimeout, c.maxTimeout, c.noDeadline...),
+		UnaryAuth(c.tokenManager, c.publicMethods...),
+		UnaryValidation(),
+	)
+}
+
+// Stream mengembalikan interceptor stream sesuai urutan Chain
+func (c *Chain) Stream() []grpc.StreamServerInterceptor {
+	interceptors := []grpc.StreamServerInterceptor{
+		StreamRecovery(c.logger),
+		StreamRequestID(),
+		StreamLogging(c.logger),
+	}
+	if c.metrics != nil {
+		interceptors = append(interceptors, StreamMetrics(c.metrics))
+	}
+	return append(interceptors,
+		StreamErrors(),
+		StreamRecovery(c.logger),
+		StreamDeadline(c.defaultTimeout, c.maxTimeout, c.noDeadline...),
+		StreamAuth(c.tokenManager, c.publicMethods...),
+		StreamValidation(),
+	)
+}
+
+// ServerOptions mengembalikan option untuk grpc.NewServer
+func (c *Chain) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.Unary()...),
+		grpc.ChainStreamInterceptor(c.Stream()...),
+	}
+}
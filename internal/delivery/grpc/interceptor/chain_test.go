@@ -0,0 +1,240 @@
+package interceptor
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeUserService mengembalikan informasi context yang diterima handler
+// agar efek setiap interceptor dapat diperiksa dari client
+type fakeUserService struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (s *fakeUserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	if req.GetId() == "panic" {
+		panic("boom")
+	}
+
+	user := &pb.User{Id: req.GetId()}
+	if principal, ok := auth.FromContext(ctx); ok {
+		user.Email = principal.Email
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		user.Name = time.Until(deadline).Round(time.Second).String()
+	}
+	return &pb.GetUserResponse{User: user}, nil
+}
+
+func (s *fakeUserService) StreamUsers(req *pb.StreamUsersRequest, stream pb.UserService_StreamUsersServer) error {
+	principal, _ := auth.FromContext(stream.Context())
+	return stream.Send(&pb.StreamUsersResponse{User: &pb.User{Id: principal.UserID}})
+}
+
+type chainServer struct {
+	client       pb.UserServiceClient
+	tokenManager *auth.TokenManager
+	logs         *observer.ObservedLogs
+}
+
+func setupChainServer(t *testing.T, cfg config.GRPCConfig) *chainServer {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s := setupChainServerWithCore(t, cfg, core)
+	s.logs = logs
+	return s
+}
+
+func setupChainServerWithCore(t *testing.T, cfg config.GRPCConfig, core zapcore.Core) *chainServer {
+	tokenManager, err := auth.NewTokenManager(config.AuthConfig{
+		JWTAlgorithm:    auth.AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+	require.NoError(t, err)
+
+	chain, err := NewChain(cfg, zap.New(core), tokenManager)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(chain.ServerOptions()...)
+	pb.RegisterUserServiceServer(server, &fakeUserService{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &chainServer{client: pb.NewUserServiceClient(conn), tokenManager: tokenManager}
+}
+
+func (s *chainServer) authContext(t *testing.T) context.Context {
+	token, _, err := s.tokenManager.IssueAccessToken(&entity.User{ID: "user-id", Email: "user@example.com"}, nil)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadataKey, "Bearer "+token)
+}
+
+func TestNewChain_RequiresTokenManager(t *testing.T) {
+	_, err := NewChain(config.GRPCConfig{}, zap.NewNop(), nil)
+	assert.Error(t, err)
+}
+
+func TestChain_Auth(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{})
+
+	_, err := s.client.GetUser(context.Background(), &pb.GetUserRequest{Id: "user-id"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	invalid := metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadataKey, "Bearer invalid")
+	_, err = s.client.GetUser(invalid, &pb.GetUserRequest{Id: "user-id"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := s.client.GetUser(s.authContext(t), &pb.GetUserRequest{Id: "user-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", resp.GetUser().GetEmail())
+
+	// Request anonim ditolak sebelum isinya divalidasi
+	_, err = s.client.GetUser(context.Background(), &pb.GetUserRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := s.client.StreamUsers(context.Background(), &pb.StreamUsersRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err = s.client.StreamUsers(s.authContext(t), &pb.StreamUsersRequest{})
+	require.NoError(t, err)
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", msg.GetUser().GetId())
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestChain_Recovery(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{})
+	ctx := s.authContext(t)
+
+	_, err := s.client.GetUser(ctx, &pb.GetUserRequest{Id: "panic"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+
+	// Server tetap melayani request berikutnya
+	_, err = s.client.GetUser(ctx, &pb.GetUserRequest{Id: "user-id"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, s.logs.FilterMessage("gRPC handler panic").Len())
+}
+
+// panickingCore membuat panic ketika log dengan pesan tertentu ditulis,
+// untuk mensimulasikan panic di dalam interceptor logging
+type panickingCore struct {
+	zapcore.Core
+	message string
+}
+
+func (c panickingCore) With(fields []zapcore.Field) zapcore.Core {
+	return panickingCore{Core: c.Core.With(fields), message: c.message}
+}
+
+func (c panickingCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Message == c.message {
+		panic("logging failed")
+	}
+	return c.Core.Check(entry, ce)
+}
+
+func TestChain_RecoversOuterInterceptorPanic(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	s := setupChainServerWithCore(t, config.GRPCConfig{}, panickingCore{Core: core, message: "gRPC request"})
+	ctx := s.authContext(t)
+
+	_, err := s.client.GetUser(ctx, &pb.GetUserRequest{Id: "user-id"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+
+	stream, err := s.client.StreamUsers(ctx, &pb.StreamUsersRequest{})
+	require.NoError(t, err)
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	assert.Equal(t, 2, logs.FilterMessage("gRPC handler panic").Len())
+}
+
+func TestChain_Validation(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{})
+
+	_, err := s.client.GetUser(s.authContext(t), &pb.GetUserRequest{})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = br
+		}
+	}
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "id", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestChain_Deadline(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{DefaultTimeout: 5, MaxTimeout: 10})
+
+	// Request tanpa deadline mendapat default timeout
+	resp, err := s.client.GetUser(s.authContext(t), &pb.GetUserRequest{Id: "user-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "5s", resp.GetUser().GetName())
+
+	// Deadline yang terlalu panjang dipangkas
+	ctx, cancel := context.WithTimeout(s.authContext(t), time.Hour)
+	defer cancel()
+	resp, err = s.client.GetUser(ctx, &pb.GetUserRequest{Id: "user-id"})
+	require.NoError(t, err)
+	assert.Equal(t, "10s", resp.GetUser().GetName())
+}
+
+func TestChain_Logging(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{})
+
+	_, err := s.client.GetUser(s.authContext(t), &pb.GetUserRequest{Id: "user-id"})
+	require.NoError(t, err)
+	_, err = s.client.GetUser(context.Background(), &pb.GetUserRequest{Id: "user-id"})
+	require.Error(t, err)
+
+	entries := s.logs.FilterMessage("gRPC request").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	assert.Equal(t, pb.UserService_GetUser_FullMethodName, entries[0].ContextMap()["grpc.method"])
+	assert.Equal(t, "OK", entries[0].ContextMap()["grpc.code"])
+	assert.Equal(t, zapcore.WarnLevel, entries[1].Level)
+	assert.Equal(t, "Unauthenticated", entries[1].ContextMap()["grpc.code"])
+}
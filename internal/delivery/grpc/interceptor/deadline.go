@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryDeadline memastikan setiap request memiliki deadline. Request tanpa
+// deadline mendapat defaultTimeout, dan deadline dari client yang lebih
+// panjang dari maxTimeout dipangkas. Nilai 0 menonaktifkan aturan tersebut.
+// Method pada skipMethods, misalnya stream jangka panjang, tidak diubah.
+func UnaryDeadline(defaultTimeout, maxTimeout time.Duration, skipMethods ...string) grpc.UnaryServerInterceptor {
+	skip := methodSet(skipMethods)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if skip[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		ctx, cancel := withDeadline(ctx, defaultTimeout, maxTimeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// StreamDeadline adalah versi stream dari UnaryDeadline
+func StreamDeadline(defaultTimeout, maxTimeout time.Duration, skipMethods ...string) grpc.StreamServerInterceptor {
+	skip := methodSet(skipMethods)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skip[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		ctx, cancel := withDeadline(ss.Context(), defaultTimeout, maxTimeout)
+		defer cancel()
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !ok && defaultTimeout > 0:
+		return context.WithTimeout(ctx, defaultTimeout)
+	case ok && maxTimeout > 0 && time.Until(deadline) > maxTimeout:
+		return context.WithTimeout(ctx, maxTimeout)
+	default:
+		return ctx, func() {}
+	}
+}
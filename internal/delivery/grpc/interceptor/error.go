@@ -0,0 +1,85 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain adalah domain pada ErrorInfo yang dikirim bersama status gRPC
+const ErrorDomain = "boilerplate-go"
+
+// UnaryErrors mengubah error yang dikembalikan handler dan interceptor di
+// dalamnya menjadi status gRPC. Dipasang di dalam logging dan metrics agar
+// keduanya melihat status code akhir; lihat Chain.
+func UnaryErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamErrors adalah versi stream dari UnaryErrors
+func StreamErrors() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return toStatus(ss.Context(), err)
+		}
+		return nil
+	}
+}
+
+// toStatus memanggil ToStatus dan mencatat cause dari internal error ke
+// logger milik request, karena cause tersebut tidak dikirim ke client
+func toStatus(ctx context.Context, err error) error {
+	st := ToStatus(err)
+	if status.Code(st) == codes.Internal {
+		logger.FromContext(ctx).Error("internal error", zap.Error(err))
+	}
+	return st
+}
+
+// ToStatus memetakan error domain ke status gRPC. Code pada error domain
+// dikirim sebagai ErrorInfo.Reason dan field yang gagal validasi sebagai
+// BadRequest, sehingga client gRPC mendapat informasi yang sama dengan body
+// problem+json pada HTTP. Error yang sudah berupa status dikembalikan apa adanya.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	appErr := apperror.From(err)
+	st := status.New(appErr.GRPCCode(), appErr.Message)
+	if withInfo, detailErr := st.WithDetails(&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain}); detailErr == nil {
+		st = withInfo
+	}
+	if fields, ok := appErr.Details["fields"].([]apperror.FieldError); ok {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		if withFields, detailErr := st.WithDetails(badRequest); detailErr == nil {
+			st = withFields
+		}
+	}
+	return st.Err()
+}
@@ -0,0 +1,64 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{"not found", entity.ErrUserNotFound, codes.NotFound, "user not found"},
+		{"wrapped conflict", fmt.Errorf("error creating user: %w", entity.ErrEmailAlreadyExists), codes.AlreadyExists, ""},
+		{"version conflict", entity.ErrUserVersionConflict, codes.Aborted, ""},
+		{"serialization failure", apperror.Conflict("concurrent_update", "resource was modified concurrently, please retry"), codes.Aborted, ""},
+		{"not deleted", entity.ErrUserNotDeleted, codes.FailedPrecondition, ""},
+		{"unknown error", errors.New("pq: connection refused"), codes.Internal, "internal server error"},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, ""},
+		{"status", status.Error(codes.Unavailable, "draining"), codes.Unavailable, "draining"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(ToStatus(tt.err))
+			assert.Equal(t, tt.code, st.Code())
+			if tt.message != "" {
+				assert.Equal(t, tt.message, st.Message())
+			}
+		})
+	}
+
+	assert.NoError(t, ToStatus(nil))
+}
+
+func TestToStatus_Details(t *testing.T) {
+	err := apperror.Validation([]apperror.FieldError{{Field: "email", Code: "email", Message: "must be a valid email address"}})
+
+	st := status.Convert(ToStatus(err))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "validation_failed", info.GetReason())
+	assert.Equal(t, ErrorDomain, info.GetDomain())
+
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "email", badRequest.GetFieldViolations()[0].GetField())
+}
@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLogging menyimpan logger milik request ke context, sehingga handler,
+// usecase, dan repository dapat memanggil logger.FromContext(ctx), lalu menulis
+// satu baris log terstruktur untuk setiap request beserta status code dan
+// durasinya
+func UnaryLogging(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		requestLog := requestLogger(ctx, log, info.FullMethod)
+		resp, err := handler(logger.NewContext(ctx, requestLog), req)
+		logCall(ctx, requestLog, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging adalah versi stream dari UnaryLogging. Log ditulis ketika
+// stream selesai.
+func StreamLogging(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		requestLog := requestLogger(ss.Context(), log, info.FullMethod)
+		ctx := logger.NewContext(ss.Context(), requestLog)
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ss.Context(), requestLog, "stream", start, err)
+		return err
+	}
+}
+
+// requestLogger membuat logger milik request yang membawa method, id
+// request dari UnaryRequestID/StreamRequestID, dan trace id dari stats
+// handler OpenTelemetry
+func requestLogger(ctx context.Context, log *zap.Logger, method string) *zap.Logger {
+	fields := []zap.Field{zap.String("grpc.method", method)}
+	if id, ok := requestid.FromContext(ctx); ok {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields, zap.String("trace_id", spanContext.TraceID().String()))
+	}
+	return log.With(fields...)
+}
+
+func logCall(ctx context.Context, log *zap.Logger, kind string, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("grpc.kind", kind),
+		zap.String("grpc.code", code.String()),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+
+	if ce := log.Check(logLevel(code), "gRPC request"); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+// logLevel menentukan level log dari status code: error server dicatat
+// sebagai error, error dari client sebagai warning
+func logLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.WarnLevel
+	}
+}
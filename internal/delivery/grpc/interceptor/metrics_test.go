@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryMetrics(t *testing.T) {
+	m := metrics.New()
+	interceptor := UnaryMetrics(m)
+	info := &grpc.UnaryServerInfo{FullMethod: pb.UserService_GetUser_FullMethodName}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	})
+	assert.Error(t, err)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return &pb.GetUserResponse{}, nil
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `grpc_server_handled_total{grpc_code="NotFound",grpc_method="/user.v1.UserService/GetUser"} 1`)
+	assert.Contains(t, string(body), `grpc_server_handled_total{grpc_code="OK",grpc_method="/user.v1.UserService/GetUser"} 1`)
+	assert.Contains(t, string(body), `grpc_server_handling_seconds_count{grpc_method="/user.v1.UserService/GetUser"} 2`)
+}
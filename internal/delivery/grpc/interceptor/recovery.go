@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// UnaryRecovery mengubah panic pada handler menjadi status Internal sehingga
+// satu request yang gagal tidak mematikan seluruh server. Status dibuat
+// langsung agar recovery juga dapat dipasang di luar UnaryErrors.
+func UnaryRecovery(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer recoverPanic(logger, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery adalah versi stream dari UnaryRecovery
+func StreamRecovery(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(logger, info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(logger *zap.Logger, method string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	logger.Error("gRPC handler panic",
+		zap.String("grpc.method", method),
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	*err = ToStatus(apperror.Internal(fmt.Errorf("panic: %v", r)))
+}
@@ -0,0 +1,61 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryRequestID memakai metadata "x-request-id" dari client jika valid atau
+// membuat id baru, menyimpannya ke context, lalu mengirimkannya kembali
+// sebagai header response. Harus dipasang sebelum UnaryLogging.
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id))
+		return handler(requestid.NewContext(ctx, id), req)
+	}
+}
+
+// StreamRequestID adalah versi stream dari UnaryRequestID
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id))
+		return handler(srv, &serverStream{ServerStream: ss, ctx: requestid.NewContext(ss.Context(), id)})
+	}
+}
+
+// UnaryClientRequestID meneruskan id request pada context sebagai metadata
+// "x-request-id" ketika memanggil service gRPC lain, sehingga log kedua
+// service dapat dikorelasikan
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestID adalah versi stream dari UnaryClientRequestID
+func StreamClientRequestID() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incomingRequestID(ctx context.Context) string {
+	var id string
+	if values := metadata.ValueFromIncomingContext(ctx, requestid.MetadataKey); len(values) > 0 {
+		id = values[0]
+	}
+	return requestid.Resolve(id)
+}
+
+func outgoingRequestID(ctx context.Context) context.Context {
+	id, ok := requestid.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+}
@@ -0,0 +1,56 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestChain_RequestID(t *testing.T) {
+	s := setupChainServer(t, config.GRPCConfig{})
+
+	// Id dari client dipakai ulang dan dikirim kembali sebagai header
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(s.authContext(t), requestid.MetadataKey, "client-id-1")
+	_, err := s.client.GetUser(ctx, &pb.GetUserRequest{Id: "user-id"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-id-1"}, header.Get(requestid.MetadataKey))
+
+	// Tanpa id dari client, id baru dibuat
+	header = nil
+	_, err = s.client.GetUser(s.authContext(t), &pb.GetUserRequest{Id: "user-id"}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Len(t, header.Get(requestid.MetadataKey), 1)
+	generated := header.Get(requestid.MetadataKey)[0]
+	assert.True(t, requestid.Valid(generated))
+
+	entries := s.logs.FilterMessage("gRPC request").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "client-id-1", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, generated, entries[1].ContextMap()["request_id"])
+}
+
+func TestUnaryClientRequestID(t *testing.T) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	err := UnaryClientRequestID()(ctx, pb.UserService_GetUser_FullMethodName, nil, nil, nil, invoker)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, outgoing.Get(requestid.MetadataKey))
+
+	outgoing = nil
+	err = UnaryClientRequestID()(context.Background(), pb.UserService_GetUser_FullMethodName, nil, nil, nil, invoker)
+	require.NoError(t, err)
+	assert.Empty(t, outgoing.Get(requestid.MetadataKey))
+}
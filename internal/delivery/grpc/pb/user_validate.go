@@ -0,0 +1,87 @@
+package pb
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/validation"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+)
+
+// File ini tidak di-generate. Method Validate dipanggil oleh
+// interceptor.UnaryValidation dan aturannya mengikuti validasi request DTO
+// pada handler HTTP.
+
+// validate memakai aturan dan pesan error yang sama dengan validator HTTP
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	validation.RegisterRules(v)
+	return v
+}
+
+// fieldRule adalah aturan validator untuk satu field message
+type fieldRule struct {
+	name  string
+	value any
+	tag   string
+}
+
+func validateFields(rules ...fieldRule) error {
+	var fields []apperror.FieldError
+	for _, rule := range rules {
+		err := validate.Var(rule.value, rule.tag)
+		if err == nil {
+			continue
+		}
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return fmt.Errorf("error validating field %s: %w", rule.name, err)
+		}
+		for _, fe := range validationErrors {
+			fields = append(fields, apperror.FieldError{
+				Field:   rule.name,
+				Code:    fe.Tag(),
+				Message: validation.Message(fe),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields)
+	}
+	return nil
+}
+
+func (r *CreateUserRequest) Validate() error {
+	return validateFields(
+		fieldRule{"email", r.GetEmail(), "required,email,max=255"},
+		fieldRule{"name", r.GetName(), "required,max=255"},
+		fieldRule{"password", r.GetPassword(), "required,min=8,max=72,password"},
+		fieldRule{"role", r.GetRole(), "omitempty,oneof=admin user"},
+	)
+}
+
+func (r *GetUserRequest) Validate() error {
+	return validateFields(fieldRule{"id", r.GetId(), "required"})
+}
+
+func (r *UpdateUserRequest) Validate() error {
+	return validateFields(
+		fieldRule{"id", r.GetId(), "required"},
+		fieldRule{"email", r.GetEmail(), "required,email,max=255"},
+		fieldRule{"name", r.GetName(), "required,max=255"},
+		fieldRule{"password", r.GetPassword(), "omitempty,min=8,max=72,password"},
+		fieldRule{"role", r.GetRole(), "omitempty,oneof=admin user"},
+		fieldRule{"version", r.GetVersion(), "gte=0"},
+	)
+}
+
+func (r *DeleteUserRequest) Validate() error {
+	return validateFields(fieldRule{"id", r.GetId(), "required"})
+}
+
+func (r *ListUsersRequest) Validate() error {
+	return validateFields(fieldRule{"page_size", r.GetPageSize(), "gte=0,lte=100"})
+}
@@ -0,0 +1,33 @@
+package pb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserRequest_Validate(t *testing.T) {
+	valid := &CreateUserRequest{Email: "john@example.com", Name: "John", Password: "Password123"}
+	assert.NoError(t, valid.Validate())
+
+	err := (&CreateUserRequest{Email: "invalid", Password: "password", Role: "root"}).Validate()
+	var appErr *apperror.Error
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.KindValidation, appErr.Kind)
+
+	var codes []string
+	for _, field := range appErr.Details["fields"].([]apperror.FieldError) {
+		codes = append(codes, field.Field+":"+field.Code)
+	}
+	assert.Equal(t, []string{"email:email", "name:required", "password:password", "role:oneof"}, codes)
+}
+
+func TestUpdateUserRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&UpdateUserRequest{Id: "user-id", Email: "john@example.com", Name: "John"}).Validate())
+	assert.Error(t, (&UpdateUserRequest{Email: "john@example.com", Name: "John"}).Validate())
+	assert.Error(t, (&UpdateUserRequest{Id: "user-id", Email: "john@example.com", Name: "John", Version: -1}).Validate())
+	assert.Error(t, (&ListUsersRequest{PageSize: 101}).Validate())
+}
@@ -0,0 +1,236 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/pagination"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+	"google.golang.org/grpc"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+
+	// streamPageSize adalah jumlah user yang diambil per query pada StreamUsers
+	streamPageSize = 100
+)
+
+// PublicMethods adalah method yang boleh dipanggil tanpa bearer token
+var PublicMethods = []string{
+	pb.UserService_CreateUser_FullMethodName,
+}
+
+// UserServer mengimplementasikan pb.UserServiceServer di atas UserUseCase
+// yang sama dengan handler HTTP. Error dikembalikan sebagai error domain dan
+// diubah menjadi status gRPC oleh interceptor.UnaryErrors.
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+
+	userUseCase usecase_interface.UserUseCase
+	cursorCodec *cursor.Codec
+}
+
+// NewUserServer membuat instance baru dari UserServer
+func NewUserServer(userUseCase usecase_interface.UserUseCase, cursorCodec *cursor.Codec) *UserServer {
+	return &UserServer{
+		userUseCase: userUseCase,
+		cursorCodec: cursorCodec,
+	}
+}
+
+// Register mendaftarkan UserService ke server gRPC
+func (s *UserServer) Register(server *grpc.Server) {
+	pb.RegisterUserServiceServer(server, s)
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	user := &entity.User{
+		Email:    req.GetEmail(),
+		Name:     req.GetName(),
+		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+	}
+	if err := s.userUseCase.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return &pb.CreateUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	if err := authorizeUser(ctx, entity.PermissionUsersRead, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userUseCase.GetUserByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	if err := authorizeUser(ctx, entity.PermissionUsersUpdate, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	user := &entity.User{
+		ID:       req.GetId(),
+		Email:    req.GetEmail(),
+		Name:     req.GetName(),
+		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+		Version:  int(req.GetVersion()),
+	}
+	if err := s.userUseCase.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	if err := authorize(ctx, entity.PermissionUsersDelete); err != nil {
+		return nil, err
+	}
+
+	if err := s.userUseCase.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserResponse{}, nil
+}
+
+func (s *UserServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if err := authorize(ctx, entity.PermissionUsersList); err != nil {
+		return nil, err
+	}
+
+	query, err := s.pageQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.userUseCase.ListUsersPage(ctx, query)
+	if err != nil {
+		return nil, listError(err)
+	}
+
+	resp := &pb.ListUsersResponse{Users: toProtoUsers(page.Items), Total: page.Total}
+	if resp.NextPageToken, err = pagination.Encode(s.cursorCodec, page.NextCursor, query.ListQuery); err != nil {
+		return nil, err
+	}
+	if resp.PrevPageToken, err = pagination.Encode(s.cursorCodec, page.PrevCursor, query.ListQuery); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamUsers membaca user per halaman dengan cursor dan mengirimkannya satu
+// per satu, sehingga jumlah user yang ditahan di memori tetap kecil
+func (s *UserServer) StreamUsers(req *pb.StreamUsersRequest, stream pb.UserService_StreamUsersServer) error {
+	ctx := stream.Context()
+	if err := authorize(ctx, entity.PermissionUsersList); err != nil {
+		return err
+	}
+
+	query := repoInterface.PageQuery{
+		ListQuery: repoInterface.ListQuery{
+			Filters:        toFilters(req.GetFilters()),
+			Search:         req.GetQuery(),
+			Limit:          streamPageSize,
+			IncludeDeleted: req.GetIncludeDeleted(),
+		},
+		UseCursor: true,
+	}
+
+	for {
+		page, err := s.userUseCase.ListUsersPage(ctx, query)
+		if err != nil {
+			return listError(err)
+		}
+		for _, user := range page.Items {
+			if err := stream.Send(&pb.StreamUsersResponse{User: toProtoUser(user)}); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == nil {
+			return nil
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// pageQuery menyusun PageQuery dari ListUsersRequest. gRPC selalu memakai
+// pagination berbasis cursor.
+func (s *UserServer) pageQuery(req *pb.ListUsersRequest) (repoInterface.PageQuery, error) {
+	limit := int(req.GetPageSize())
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 1 || limit > MaxPageSize {
+		return repoInterface.PageQuery{}, apperror.BadRequest("invalid_query", "page_size must be between 1 and 100")
+	}
+
+	query := repoInterface.PageQuery{
+		ListQuery: repoInterface.ListQuery{
+			Filters:        toFilters(req.GetFilters()),
+			Search:         req.GetQuery(),
+			Limit:          limit,
+			IncludeDeleted: req.GetIncludeDeleted(),
+		},
+		UseCursor:    true,
+		IncludeTotal: req.GetIncludeTotal(),
+	}
+
+	if req.GetPageToken() != "" {
+		c, err := pagination.Decode(s.cursorCodec, req.GetPageToken(), query.ListQuery)
+		if errors.Is(err, pagination.ErrQueryMismatch) {
+			return query, apperror.Wrap(err, apperror.KindBadRequest, "invalid_page_token", "page_token was issued for different filters, query or include_deleted")
+		}
+		if err != nil {
+			return query, apperror.Wrap(err, apperror.KindBadRequest, "invalid_page_token", "page_token is invalid or has been tampered with")
+		}
+		query.Cursor = c
+	}
+	return query, nil
+}
+
+// listError mengubah InvalidQueryError dari repository menjadi bad request
+func listError(err error) error {
+	var invalidQuery *repoInterface.InvalidQueryError
+	if errors.As(err, &invalidQuery) {
+		return apperror.Wrap(err, apperror.KindBadRequest, "invalid_query", invalidQuery.Error())
+	}
+	return err
+}
+
+// authorize memastikan request terautentikasi dan memiliki permission
+func authorize(ctx context.Context, permission string) error {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return auth.ErrMissingToken
+	}
+	if !principal.HasPermission(permission) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeUser sama seperti authorize, tetapi selain admin hanya pemilik
+// record yang diizinkan
+func authorizeUser(ctx context.Context, permission, userID string) error {
+	if err := authorize(ctx, permission); err != nil {
+		return err
+	}
+	principal, _ := auth.FromContext(ctx)
+	if !principal.CanAccessUser(userID) {
+		return entity.ErrForbidden
+	}
+	return nil
+}
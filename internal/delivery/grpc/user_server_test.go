@@ -0,0 +1,343 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/interceptor"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/grpc/pb"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MockUserUseCase adalah mock untuk UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) PatchUser(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) RestoreUser(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsers(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterface.Page[entity.User]), args.Error(1)
+}
+
+func (m *MockUserUseCase) VerifyCredentials(ctx context.Context, email, password string) (*entity.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	args := m.Called(ctx, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+type testServer struct {
+	client       pb.UserServiceClient
+	tokenManager *auth.TokenManager
+	codec        *cursor.Codec
+}
+
+// setupUserServer menjalankan UserServer beserta rantai interceptor di atas
+// bufconn
+func setupUserServer(t *testing.T, userUseCase *MockUserUseCase) *testServer {
+	tokenManager, err := auth.NewTokenManager(config.AuthConfig{
+		JWTAlgorithm:    auth.AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+	require.NoError(t, err)
+	codec, err := cursor.NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	chain, err := interceptor.NewChain(config.GRPCConfig{DefaultTimeout: 5}, zap.NewNop(), tokenManager, PublicMethods...)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(chain.ServerOptions()...)
+	NewUserServer(userUseCase, codec).Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &testServer{client: pb.NewUserServiceClient(conn), tokenManager: tokenManager, codec: codec}
+}
+
+func (s *testServer) withToken(t *testing.T, user *entity.User, permissions ...string) context.Context {
+	token, _, err := s.tokenManager.IssueAccessToken(user, permissions)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestUserServer_CreateUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	mockUseCase.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Email == "admin@example.com" && u.Role == entity.RoleAdmin
+	})).Return(entity.ErrForbidden)
+	mockUseCase.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Email == "new@example.com" && u.Role == ""
+	})).Run(func(args mock.Arguments) {
+		user := args.Get(1).(*entity.User)
+		user.ID = "new-id"
+		user.Role = entity.RoleUser
+		user.Version = 1
+	}).Return(nil)
+
+	// CreateUser adalah method publik
+	resp, err := s.client.CreateUser(context.Background(), &pb.CreateUserRequest{
+		Email: "new@example.com", Name: "New User", Password: "Password123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", resp.GetUser().GetId())
+	assert.Equal(t, int64(1), resp.GetUser().GetVersion())
+
+	// Penolakan role dari usecase diteruskan sebagai PermissionDenied
+	_, err = s.client.CreateUser(context.Background(), &pb.CreateUserRequest{
+		Email: "admin@example.com", Name: "Admin", Password: "Password123", Role: entity.RoleAdmin,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserServer_Authorization(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	mockUseCase.On("GetUserByID", mock.Anything, "user-id").Return(&entity.User{ID: "user-id"}, nil)
+	mockUseCase.On("GetUserByID", mock.Anything, "unknown-id").Return(nil, entity.ErrUserNotFound)
+
+	user := s.withToken(t, &entity.User{ID: "user-id", Role: entity.RoleUser}, entity.PermissionUsersRead)
+	admin := s.withToken(t, &entity.User{ID: "admin-id", Role: entity.RoleAdmin}, entity.PermissionUsersRead)
+	invalid := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		id   string
+		code codes.Code
+	}{
+		{"anonymous", context.Background(), "user-id", codes.Unauthenticated},
+		{"invalid token", invalid, "user-id", codes.Unauthenticated},
+		{"self", user, "user-id", codes.OK},
+		{"other user", user, "other-id", codes.PermissionDenied},
+		{"admin", admin, "user-id", codes.OK},
+		{"not found", admin, "unknown-id", codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.client.GetUser(tt.ctx, &pb.GetUserRequest{Id: tt.id})
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	// Streaming juga membutuhkan token
+	stream, err := s.client.StreamUsers(context.Background(), &pb.StreamUsersRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUserServer_UpdateUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	mockUseCase.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool { return u.Role == entity.RoleAdmin })).
+		Return(entity.ErrForbidden)
+	mockUseCase.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool { return u.Version == 2 })).
+		Return(entity.ErrUserVersionConflict)
+	mockUseCase.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *entity.User) bool { return u.Version == 3 })).
+		Run(func(args mock.Arguments) { args.Get(1).(*entity.User).Version = 4 }).
+		Return(nil)
+
+	ctx := s.withToken(t, &entity.User{ID: "user-id", Role: entity.RoleUser}, entity.PermissionUsersUpdate)
+
+	_, err := s.client.UpdateUser(ctx, &pb.UpdateUserRequest{Id: "user-id", Email: "user@example.com", Name: "User", Version: 2})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	resp, err := s.client.UpdateUser(ctx, &pb.UpdateUserRequest{Id: "user-id", Email: "user@example.com", Name: "User", Version: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.GetUser().GetVersion())
+
+	_, err = s.client.UpdateUser(ctx, &pb.UpdateUserRequest{Id: "user-id", Email: "user@example.com", Name: "User", Role: entity.RoleAdmin})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserServer_DeleteUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	mockUseCase.On("DeleteUser", mock.Anything, "user-id").Return(nil)
+
+	admin := s.withToken(t, &entity.User{ID: "admin-id", Role: entity.RoleAdmin}, entity.PermissionUsersDelete)
+	_, err := s.client.DeleteUser(admin, &pb.DeleteUserRequest{Id: "user-id"})
+	assert.NoError(t, err)
+
+	user := s.withToken(t, &entity.User{ID: "user-id", Role: entity.RoleUser}, entity.PermissionUsersRead)
+	_, err = s.client.DeleteUser(user, &pb.DeleteUserRequest{Id: "user-id"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserServer_ListUsers(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	next := &repoInterface.Cursor{CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), ID: "user-2"}
+	total := int64(3)
+	mockUseCase.On("ListUsersPage", mock.Anything, mock.MatchedBy(func(q repoInterface.PageQuery) bool {
+		return q.Cursor == nil && q.Limit == 2 && q.Search == "doe" && q.IncludeTotal
+	})).Return(&repoInterface.Page[entity.User]{
+		Items:      []*entity.User{{ID: "user-1"}, {ID: "user-2"}},
+		NextCursor: next,
+		Total:      &total,
+	}, nil)
+	mockUseCase.On("ListUsersPage", mock.Anything, mock.MatchedBy(func(q repoInterface.PageQuery) bool {
+		return q.Cursor != nil && q.Cursor.ID == "user-2"
+	})).Return(&repoInterface.Page[entity.User]{Items: []*entity.User{{ID: "user-3"}}}, nil)
+
+	ctx := s.withToken(t, &entity.User{ID: "admin-id", Role: entity.RoleAdmin}, entity.PermissionUsersList)
+
+	resp, err := s.client.ListUsers(ctx, &pb.ListUsersRequest{PageSize: 2, Query: "doe", IncludeTotal: true})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetUsers(), 2)
+	assert.Equal(t, int64(3), resp.GetTotal())
+	require.NotEmpty(t, resp.GetNextPageToken())
+
+	token := resp.GetNextPageToken()
+	resp, err = s.client.ListUsers(ctx, &pb.ListUsersRequest{Query: "doe", PageToken: token})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetUsers(), 1)
+	assert.Empty(t, resp.GetNextPageToken())
+	assert.Nil(t, resp.Total)
+
+	// Token tidak dapat dipakai ulang dengan query atau filter yang berbeda
+	for _, req := range []*pb.ListUsersRequest{
+		{PageToken: token},
+		{Query: "smith", PageToken: token},
+		{Query: "doe", IncludeDeleted: true, PageToken: token},
+		{Query: "doe", Filters: []*pb.Filter{{Field: "role", Operator: "eq", Value: "admin"}}, PageToken: token},
+	} {
+		_, err = s.client.ListUsers(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+	}
+
+	_, err = s.client.ListUsers(ctx, &pb.ListUsersRequest{PageToken: "tampered"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.client.ListUsers(ctx, &pb.ListUsersRequest{PageSize: MaxPageSize + 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUserServer_StreamUsers(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	s := setupUserServer(t, mockUseCase)
+
+	next := &repoInterface.Cursor{CreatedAt: time.Now(), ID: "user-2"}
+	mockUseCase.On("ListUsersPage", mock.Anything, mock.MatchedBy(func(q repoInterface.PageQuery) bool {
+		return q.Cursor == nil && q.Limit == streamPageSize && q.IncludeDeleted
+	})).Return(&repoInterface.Page[entity.User]{Items: []*entity.User{{ID: "user-1"}, {ID: "user-2"}}, NextCursor: next}, nil)
+	mockUseCase.On("ListUsersPage", mock.Anything, mock.MatchedBy(func(q repoInterface.PageQuery) bool {
+		return q.Cursor == next
+	})).Return(&repoInterface.Page[entity.User]{Items: []*entity.User{{ID: "user-3"}}}, nil)
+
+	ctx := s.withToken(t, &entity.User{ID: "admin-id", Role: entity.RoleAdmin}, entity.PermissionUsersList)
+	stream, err := s.client.StreamUsers(ctx, &pb.StreamUsersRequest{IncludeDeleted: true})
+	require.NoError(t, err)
+
+	var ids []string
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			break
+		}
+		ids = append(ids, resp.GetUser().GetId())
+	}
+	assert.Equal(t, []string{"user-1", "user-2", "user-3"}, ids)
+
+	mockUseCase.AssertExpectations(t)
+}
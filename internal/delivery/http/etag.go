@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// errPreconditionFailed dikembalikan ketika If-Match tidak cocok dengan versi
+// user yang tersimpan
+var errPreconditionFailed = apperror.PreconditionFailed("precondition_failed",
+	"resource has been modified; If-Match does not match the current ETag")
+
+// userETag membentuk strong ETag dari versi user
+func userETag(user *entity.User) string {
+	return `"` + strconv.Itoa(user.Version) + `"`
+}
+
+// setUserETag menambahkan header ETag pada response
+func setUserETag(c *gin.Context, user *entity.User) {
+	c.Header("ETag", userETag(user))
+}
+
+// parseIfMatch membaca header If-Match menjadi versi yang diharapkan. Header
+// kosong atau "*" berarti tidak ada syarat versi (0). Hanya satu ETag yang
+// didukung karena update bersyarat dilakukan terhadap satu versi.
+func parseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	// If-Match memakai strong comparison, sehingga weak ETag (W/"...")
+	// tidak pernah cocok
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || strings.Contains(header, ",") {
+		return 0, errPreconditionFailed
+	}
+
+	version, err := strconv.Atoi(strings.Trim(header, `"`))
+	if err != nil || version < 1 {
+		return 0, errPreconditionFailed
+	}
+	return version, nil
+}
+
+// ifNoneMatch bernilai true jika salah satu ETag pada header If-None-Match
+// cocok dengan user. Perbandingan If-None-Match memakai weak comparison.
+func ifNoneMatch(header string, user *entity.User) bool {
+	etag := userETag(user)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// preconditionError mengubah konflik versi menjadi 412 jika client
+// mengirimkan If-Match
+func preconditionError(err error, version int) error {
+	if version != 0 && errors.Is(err, entity.ErrUserVersionConflict) {
+		return errPreconditionFailed.WithCause(err)
+	}
+	return err
+}
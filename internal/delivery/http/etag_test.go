@@ -0,0 +1,47 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"*", 0, false},
+		{`"3"`, 3, false},
+		{` "12" `, 12, false},
+		{`W/"3"`, 0, true},
+		{`"3", "4"`, 0, true},
+		{`3`, 0, true},
+		{`"abc"`, 0, true},
+		{`"0"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		version, err := parseIfMatch(tt.header)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, errPreconditionFailed, tt.header)
+			continue
+		}
+		assert.NoError(t, err, tt.header)
+		assert.Equal(t, tt.version, version, tt.header)
+	}
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	user := &entity.User{Version: 3}
+
+	assert.True(t, ifNoneMatch(`"3"`, user))
+	assert.True(t, ifNoneMatch(`W/"3"`, user))
+	assert.True(t, ifNoneMatch(`"1", "3"`, user))
+	assert.True(t, ifNoneMatch(`*`, user))
+	assert.False(t, ifNoneMatch(`"2"`, user))
+	assert.False(t, ifNoneMatch(``, user))
+}
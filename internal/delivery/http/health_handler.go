@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/pkg/health"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type HealthHandler struct {
+	registry     *health.Registry
+	exposeErrors bool
+}
+
+// NewHealthHandler membuat instance baru dari HealthHandler
+func NewHealthHandler(registry *health.Registry, cfg config.HealthConfig) *HealthHandler {
+	return &HealthHandler{
+		registry:     registry,
+		exposeErrors: cfg.ExposeErrors,
+	}
+}
+
+// RegisterRoutes mendaftarkan route liveness dan readiness. Route ini
+// didaftarkan di luar /api/v1 dan tanpa autentikasi karena dipanggil oleh
+// probe Kubernetes dan load balancer.
+func (h *HealthHandler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/healthz", h.Liveness)
+	router.GET("/readyz", h.Readiness)
+}
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Description Report whether the process is running. Dependencies are not checked so that a database outage does not restart the pod.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, health.Report{Status: health.StatusUp})
+}
+
+// Readiness godoc
+// @Summary Readiness probe
+// @Description Check every registered dependency and report the status of each check. Returns 503 when a check fails or the server is shutting down. Error details are logged and only included in the response when health.expose_errors is enabled.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.registry.Ready(c.Request.Context())
+	if report.Healthy() {
+		c.JSON(http.StatusOK, h.response(report))
+		return
+	}
+
+	log := logger.FromContext(c.Request.Context())
+	for name, result := range report.Checks {
+		if result.Status != health.StatusUp {
+			log.Warn("readiness check failed", zap.String("dependency", name), zap.String("error", result.Error))
+		}
+	}
+	c.JSON(http.StatusServiceUnavailable, h.response(report))
+}
+
+// response menghapus pesan error dari report kecuali expose_errors aktif,
+// karena /readyz dapat dipanggil tanpa autentikasi
+func (h *HealthHandler) response(report health.Report) health.Report {
+	if h.exposeErrors {
+		return report
+	}
+	return report.Redacted()
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/pkg/health"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var dbErr error
+	registry := health.NewRegistry(time.Second, 0)
+	registry.Register("database", func(ctx context.Context) error { return dbErr })
+
+	router := gin.New()
+	NewHealthHandler(registry, config.HealthConfig{}).RegisterRoutes(router)
+
+	get := func(path string) (*httptest.ResponseRecorder, health.Report) {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		return rec, report
+	}
+
+	rec, report := get("/readyz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+
+	dbErr = errors.New("connection refused")
+	rec, report = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, health.StatusDown, report.Checks["database"].Status)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+
+	// Liveness tidak bergantung pada dependency
+	rec, report = get("/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, health.StatusUp, report.Status)
+
+	dbErr = nil
+	registry.Shutdown()
+	rec, report = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, report.Checks, "shutdown")
+
+	rec, _ = get("/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHealthHandler_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := health.NewRegistry(time.Second, 0)
+	registry.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	readyz := func(handler *HealthHandler, ctx context.Context) *httptest.ResponseRecorder {
+		router := gin.New()
+		handler.RegisterRoutes(router)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx))
+		return rec
+	}
+
+	// Error dicatat pada logger request
+	core, logs := observer.New(zap.WarnLevel)
+	ctx := logger.NewContext(context.Background(), zap.New(core))
+	rec := readyz(NewHealthHandler(registry, config.HealthConfig{}), ctx)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "readiness check failed", logs.All()[0].Message)
+	assert.Equal(t, "connection refused", logs.All()[0].ContextMap()["error"])
+
+	rec = readyz(NewHealthHandler(registry, config.HealthConfig{ExposeErrors: true}), context.Background())
+	var report health.Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+}
@@ -0,0 +1,183 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/pagination"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+)
+
+const (
+	DefaultListLimit = 10
+	MaxListLimit     = 100
+)
+
+// reservedQueryParams adalah query parameter yang bukan filter
+var reservedQueryParams = map[string]bool{
+	"offset":          true,
+	"limit":           true,
+	"sort":            true,
+	"q":               true,
+	"cursor":          true,
+	"include_total":   true,
+	"include_deleted": true,
+}
+
+// filterPattern memisahkan "<field><operator><value>". Operator dua karakter
+// dicocokkan lebih dulu agar "created_at>=x" tidak terbaca sebagai ">".
+var filterPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(>=|<=|!=|~=|\^=|=|>|<)(.*)$`)
+
+var filterOperators = map[string]repoInterface.Operator{
+	"=":  repoInterface.OpEq,
+	"!=": repoInterface.OpNe,
+	">":  repoInterface.OpGt,
+	">=": repoInterface.OpGte,
+	"<":  repoInterface.OpLt,
+	"<=": repoInterface.OpLte,
+	"~=": repoInterface.OpContains,
+	"^=": repoInterface.OpPrefix,
+}
+
+// listRequest adalah hasil parsing query string pada endpoint list
+type listRequest struct {
+	query        repoInterface.ListQuery
+	cursor       string
+	hasOffset    bool
+	hasSort      bool
+	includeTotal bool
+}
+
+// parseListQuery membaca filter, pencarian, pengurutan dan pagination dari
+// query string mentah, misalnya:
+//
+//	?email^=john&name~=doe&created_at>=2024-01-01&sort=-created_at,name&q=smith
+//
+// Query string dibaca mentah karena operator seperti ">=" tidak dapat
+// dipisahkan dengan benar oleh url.ParseQuery. Validasi nama field dan
+// operator dilakukan oleh repository terhadap whitelist entitasnya.
+func parseListQuery(rawQuery string) (listRequest, error) {
+	req := listRequest{query: repoInterface.ListQuery{Offset: 0, Limit: DefaultListLimit}}
+
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		decoded, err := url.QueryUnescape(pair)
+		if err != nil {
+			return req, fmt.Errorf("invalid query parameter %q: %w", pair, err)
+		}
+
+		match := filterPattern.FindStringSubmatch(decoded)
+		if match == nil {
+			return req, fmt.Errorf("invalid query parameter %q", decoded)
+		}
+		field, op, value := match[1], match[2], match[3]
+
+		if reservedQueryParams[field] {
+			if op != "=" {
+				return req, fmt.Errorf("invalid query parameter %q", decoded)
+			}
+			if err := req.applyReservedParam(field, value); err != nil {
+				return req, err
+			}
+			continue
+		}
+
+		req.query.Filters = append(req.query.Filters, repoInterface.Filter{
+			Field:    field,
+			Operator: filterOperators[op],
+			Value:    value,
+		})
+	}
+
+	if req.cursor != "" && (req.hasOffset || req.hasSort) {
+		return req, errors.New("cursor cannot be combined with offset or sort")
+	}
+
+	return req, nil
+}
+
+func (req *listRequest) applyReservedParam(name, value string) error {
+	switch name {
+	case "offset":
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return fmt.Errorf("offset must be a non-negative integer")
+		}
+		req.query.Offset = offset
+		req.hasOffset = true
+	case "limit":
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxListLimit {
+			return fmt.Errorf("limit must be an integer between 1 and %d", MaxListLimit)
+		}
+		req.query.Limit = limit
+	case "q":
+		req.query.Search = value
+	case "cursor":
+		req.cursor = value
+	case "include_total":
+		includeTotal, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("include_total must be a boolean")
+		}
+		req.includeTotal = includeTotal
+	case "include_deleted":
+		includeDeleted, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("include_deleted must be a boolean")
+		}
+		req.query.IncludeDeleted = includeDeleted
+	case "sort":
+		sort, err := parseSort(value)
+		if err != nil {
+			return err
+		}
+		req.query.Sort = sort
+		req.hasSort = true
+	}
+	return nil
+}
+
+// pageQuery menyusun PageQuery. Pagination berbasis cursor dipakai kecuali
+// client mengirim offset atau sort, agar client lama tetap berjalan.
+func (req listRequest) pageQuery(codec *cursor.Codec) (repoInterface.PageQuery, error) {
+	query := repoInterface.PageQuery{
+		ListQuery:    req.query,
+		UseCursor:    !req.hasOffset && !req.hasSort,
+		IncludeTotal: req.includeTotal,
+	}
+
+	if req.cursor != "" {
+		c, err := pagination.Decode(codec, req.cursor, req.query)
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = c
+	}
+
+	return query, nil
+}
+
+// parseSort membaca daftar field dipisah koma; awalan "-" berarti descending
+func parseSort(value string) ([]repoInterface.SortField, error) {
+	var sort []repoInterface.SortField
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field := repoInterface.SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if field.Field == "" {
+			return nil, fmt.Errorf("invalid sort field %q", part)
+		}
+		sort = append(sort, field)
+	}
+	return sort, nil
+}
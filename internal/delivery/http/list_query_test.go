@@ -0,0 +1,124 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/pagination"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListQuery(t *testing.T) {
+	req, err := parseListQuery("email%5E=john&name~=jane%20doe&created_at>=2024-01-01&created_at<2024-02-01T00:00:00Z&role!=admin&sort=-created_at,name&q=smith&offset=20&limit=5")
+	require.NoError(t, err)
+	query := req.query
+
+	assert.Equal(t, []repoInterface.Filter{
+		{Field: "email", Operator: repoInterface.OpPrefix, Value: "john"},
+		{Field: "name", Operator: repoInterface.OpContains, Value: "jane doe"},
+		{Field: "created_at", Operator: repoInterface.OpGte, Value: "2024-01-01"},
+		{Field: "created_at", Operator: repoInterface.OpLt, Value: "2024-02-01T00:00:00Z"},
+		{Field: "role", Operator: repoInterface.OpNe, Value: "admin"},
+	}, query.Filters)
+	assert.Equal(t, []repoInterface.SortField{
+		{Field: "created_at", Desc: true},
+		{Field: "name"},
+	}, query.Sort)
+	assert.Equal(t, "smith", query.Search)
+	assert.Equal(t, 20, query.Offset)
+	assert.Equal(t, 5, query.Limit)
+}
+
+func TestParseListQuery_Defaults(t *testing.T) {
+	req, err := parseListQuery("")
+	require.NoError(t, err)
+	assert.Empty(t, req.query.Filters)
+	assert.Equal(t, 0, req.query.Offset)
+	assert.Equal(t, DefaultListLimit, req.query.Limit)
+}
+
+func TestParseListQuery_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"email",
+		"sort>=name",
+		"%zz=1",
+		"sort=-",
+		"limit=abc",
+		"limit=0",
+		"limit=-5",
+		"limit=101",
+		"offset=-1",
+		"offset=abc",
+		"include_total=maybe",
+		"include_deleted=maybe",
+		"cursor=abc&offset=10",
+		"cursor=abc&sort=name",
+	} {
+		_, err := parseListQuery(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestListRequest_PageQuery(t *testing.T) {
+	codec, err := cursor.NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	// Tanpa offset dan sort, pagination memakai cursor
+	req, err := parseListQuery("limit=20&include_total=true&include_deleted=true")
+	require.NoError(t, err)
+	query, err := req.pageQuery(codec)
+	require.NoError(t, err)
+	assert.True(t, query.UseCursor)
+	assert.True(t, query.IncludeTotal)
+	assert.True(t, query.IncludeDeleted)
+	assert.Nil(t, query.Cursor)
+
+	// Cursor hasil encode dapat dipakai kembali
+	next := &repoInterface.Cursor{CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 123456000, time.UTC), ID: "user-id"}
+	req, err = parseListQuery("email^=john&name~=doe")
+	require.NoError(t, err)
+	token, err := pagination.Encode(codec, next, req.query)
+	require.NoError(t, err)
+
+	// Urutan filter tidak berpengaruh, limit boleh berubah
+	req, err = parseListQuery("name~=doe&email^=john&limit=50&cursor=" + url.QueryEscape(token))
+	require.NoError(t, err)
+	query, err = req.pageQuery(codec)
+	require.NoError(t, err)
+	assert.True(t, query.UseCursor)
+	require.NotNil(t, query.Cursor)
+	assert.True(t, next.CreatedAt.Equal(query.Cursor.CreatedAt))
+	assert.Equal(t, "user-id", query.Cursor.ID)
+
+	// Cursor tidak dapat dipakai ulang dengan filter, pencarian atau
+	// include_deleted yang berbeda
+	for _, raw := range []string{
+		"email^=john",
+		"email^=jane&name~=doe",
+		"email^=john&name~=doe&q=smith",
+		"email^=john&name~=doe&include_deleted=true",
+	} {
+		req, err = parseListQuery(raw + "&cursor=" + url.QueryEscape(token))
+		require.NoError(t, err)
+		_, err = req.pageQuery(codec)
+		assert.ErrorIs(t, err, pagination.ErrQueryMismatch, raw)
+	}
+
+	// Cursor yang diubah ditolak
+	req, err = parseListQuery("email^=john&name~=doe&cursor=" + url.QueryEscape(token+"x"))
+	require.NoError(t, err)
+	_, err = req.pageQuery(codec)
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+
+	// Offset tetap didukung untuk client lama
+	req, err = parseListQuery("offset=10")
+	require.NoError(t, err)
+	query, err = req.pageQuery(codec)
+	require.NoError(t, err)
+	assert.False(t, query.UseCursor)
+	assert.Equal(t, 10, query.Offset)
+}
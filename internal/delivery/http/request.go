@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+)
+
+// bindJSON membaca body JSON ke req. Jika gagal, error dicatat ke gin.Context
+// untuk ditulis oleh middleware.ErrorHandler dan bindJSON mengembalikan false.
+func bindJSON(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		_ = c.Error(apperror.Wrap(err, apperror.KindBadRequest, "invalid_request_body",
+			"request body is malformed or missing required fields"))
+		return false
+	}
+	return true
+}
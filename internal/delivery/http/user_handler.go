@@ -1,34 +1,67 @@
 package http
 
 import (
+	"errors"
+	"io"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/pagination"
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/validation"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/internal/middleware"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
 	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
 )
 
 type UserHandler struct {
 	userUseCase usecase_interface.UserUseCase
+	cursorCodec *cursor.Codec
+	validator   *validation.Validator
 }
 
 // NewUserHandler membuat instance baru dari UserHandler
-func NewUserHandler(userUseCase usecase_interface.UserUseCase) *UserHandler {
+func NewUserHandler(userUseCase usecase_interface.UserUseCase, cursorCodec *cursor.Codec) *UserHandler {
 	return &UserHandler{
 		userUseCase: userUseCase,
+		cursorCodec: cursorCodec,
+		validator:   validation.New(userUseCase),
 	}
 }
 
-// RegisterRoutes mendaftarkan route untuk user
-func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
+// RegisterRoutes mendaftarkan route untuk user. Pendaftaran user bersifat
+// publik, sedangkan route lainnya membutuhkan access token dan permission
+// yang sesuai. Selain admin, user hanya boleh membaca dan mengubah datanya sendiri.
+func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup, tokenManager *auth.TokenManager) {
+	authenticate := middleware.Authenticate(tokenManager)
+
 	users := router.Group("/users")
 	{
-		users.POST("", h.CreateUser)
-		users.GET("/:id", h.GetUserByID)
-		users.PUT("/:id", h.UpdateUser)
-		users.DELETE("/:id", h.DeleteUser)
-		users.GET("", h.ListUsers)
+		users.POST("", middleware.OptionalAuthenticate(tokenManager), h.CreateUser)
+		users.GET("/:id", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersRead),
+			middleware.RequireSelfOrAdmin("id"),
+			h.GetUserByID)
+		users.PUT("/:id", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersUpdate),
+			middleware.RequireSelfOrAdmin("id"),
+			h.UpdateUser)
+		users.PATCH("/:id", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersUpdate),
+			middleware.RequireSelfOrAdmin("id"),
+			h.PatchUser)
+		users.DELETE("/:id", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersDelete),
+			h.DeleteUser)
+		users.POST("/:id/restore", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersRestore),
+			h.RestoreUser)
+		users.GET("", authenticate,
+			middleware.RequirePermission(entity.PermissionUsersList),
+			h.ListUsers)
 	}
 }
 
@@ -38,23 +71,31 @@ func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup) {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param user body entity.User true "User object"
+// @Param user body CreateUserRequest true "User object"
 // @Success 201 {object} entity.User
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
-	var user entity.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	var req CreateUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.validator.User(c.Request.Context(), &req, req.Email, ""); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	if err := h.userUseCase.CreateUser(c.Request.Context(), &user); err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	user := req.toEntity()
+	if err := h.userUseCase.CreateUser(c.Request.Context(), user); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
+	setUserETag(c, user)
 	c.JSON(http.StatusCreated, user)
 }
 
@@ -65,22 +106,28 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} entity.User
+// @Success 304 "Not Modified"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUserByID(c *gin.Context) {
 	id := c.Param("id")
 	user, err := h.userUseCase.GetUserByID(c.Request.Context(), id)
 	if err != nil {
-		if err == entity.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setUserETag(c, user)
+	if ifNoneMatch(c.GetHeader("If-None-Match"), user) {
+		c.Status(http.StatusNotModified)
+		return
+	}
 	c.JSON(http.StatusOK, user)
 }
 
@@ -91,83 +138,272 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
-// @Param user body entity.User true "User object"
+// @Param If-Match header string false "ETag of the version being replaced"
+// @Param user body UpdateUserRequest true "User object"
 // @Success 200 {object} entity.User
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	id := c.Param("id")
-	var user entity.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	var req UpdateUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.validator.User(c.Request.Context(), &req, req.Email, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	version, err := parseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
+	user := req.toEntity()
 	user.ID = id
-	if err := h.userUseCase.UpdateUser(c.Request.Context(), &user); err != nil {
-		if err == entity.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	user.Version = version
+	if err := h.userUseCase.UpdateUser(c.Request.Context(), user); err != nil {
+		_ = c.Error(preconditionError(err, version))
+		return
+	}
+
+	setUserETag(c, user)
+	c.JSON(http.StatusOK, user)
+}
+
+// PatchUser godoc
+// @Summary Partially update user
+// @Description Update only the fields present in the body using JSON Merge Patch (RFC 7396).
+// @Description Omitted fields are left unchanged; null is rejected because every user field is required.
+// @Tags users
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param If-Match header string false "ETag of the version being patched"
+// @Param user body PatchUserRequest true "Merge patch document"
+// @Success 200 {object} entity.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	id := c.Param("id")
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		_ = c.Error(apperror.Wrap(err, apperror.KindBadRequest, "invalid_request_body", "request body could not be read"))
+		return
+	}
+
+	req, err := parseUserMergePatch(body)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var email string
+	if req.Email != nil {
+		email = *req.Email
+	}
+	if err := h.validator.User(c.Request.Context(), &req, email, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	version, err := parseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	patch := req.toPatch()
+	patch.Version = version
+	user, err := h.userUseCase.PatchUser(c.Request.Context(), id, patch)
+	if err != nil {
+		_ = c.Error(preconditionError(err, version))
 		return
 	}
 
+	setUserETag(c, user)
 	c.JSON(http.StatusOK, user)
 }
 
 // DeleteUser godoc
 // @Summary Delete user
-// @Description Delete user by ID
+// @Description Soft delete user by ID. The user can be restored until it is purged.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	id := c.Param("id")
 	if err := h.userUseCase.DeleteUser(c.Request.Context(), id); err != nil {
-		if err == entity.ErrUserNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// RestoreUser godoc
+// @Summary Restore user
+// @Description Restore a soft deleted user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.User
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	user, err := h.userUseCase.RestoreUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setUserETag(c, user)
+	c.JSON(http.StatusOK, user)
+}
+
 // ListUsers godoc
 // @Summary List users
-// @Description Get list of users with pagination
+// @Description Get a page of users with filtering, search and sorting.
+// @Description Filters use the form `<field><op><value>` where op is one of `=`, `!=`, `>`, `>=`, `<`, `<=`, `~=` (contains) or `^=` (prefix),
+// @Description e.g. `email^=john&created_at>=2024-01-01`. Filterable fields: id, email, name, role, created_at, updated_at.
+// @Description Pagination is cursor based (newest first) unless `offset` or `sort` is given, in which case offset pagination is used.
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param offset query int false "Offset for pagination"
-// @Param limit query int false "Limit for pagination"
-// @Success 200 {array} entity.User
+// @Param cursor query string false "Opaque cursor from next_cursor or prev_cursor"
+// @Param offset query int false "Offset for pagination (switches to offset mode)"
+// @Param limit query int false "Page size, between 1 and 100" default(10)
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending (switches to offset mode)"
+// @Param q query string false "Search in email and name"
+// @Param include_total query bool false "Include the total number of matching users"
+// @Param include_deleted query bool false "Include soft deleted users"
+// @Success 200 {object} UserListResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	req, err := parseListQuery(c.Request.URL.RawQuery)
+	if err != nil {
+		_ = c.Error(apperror.Wrap(err, apperror.KindBadRequest, "invalid_query", err.Error()))
+		return
+	}
+	query, err := req.pageQuery(h.cursorCodec)
+	if errors.Is(err, pagination.ErrQueryMismatch) {
+		_ = c.Error(apperror.Wrap(err, apperror.KindBadRequest, "invalid_cursor", "cursor was issued for different filters, search or sort"))
+		return
+	}
+	if err != nil {
+		_ = c.Error(apperror.Wrap(err, apperror.KindBadRequest, "invalid_cursor", "cursor is invalid or has been tampered with"))
+		return
+	}
 
-	users, err := h.userUseCase.ListUsers(c.Request.Context(), offset, limit)
+	page, err := h.userUseCase.ListUsersPage(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		var invalidQuery *repoInterface.InvalidQueryError
+		if errors.As(err, &invalidQuery) {
+			err = apperror.Wrap(err, apperror.KindBadRequest, "invalid_query", invalidQuery.Error())
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	resp := UserListResponse{Items: page.Items, Total: page.Total}
+	if resp.Items == nil {
+		resp.Items = []*entity.User{}
+	}
+	if resp.NextCursor, err = pagination.Encode(h.cursorCodec, page.NextCursor, query.ListQuery); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if resp.PrevCursor, err = pagination.Encode(h.cursorCodec, page.PrevCursor, query.ListQuery); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, resp)
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// CreateUserRequest adalah payload untuk membuat user. Password tidak ikut
+// diserialisasi pada entity.User, sehingga perlu dibaca di sini. Role hanya
+// boleh diisi oleh admin.
+type CreateUserRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72,password"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=admin user"`
 }
+
+func (r CreateUserRequest) toEntity() *entity.User {
+	return &entity.User{
+		Email:    r.Email,
+		Name:     r.Name,
+		Password: r.Password,
+		Role:     r.Role,
+	}
+}
+
+// UpdateUserRequest adalah payload untuk mengupdate user. Password kosong
+// berarti password tidak diubah.
+type UpdateUserRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" validate:"required,max=255"`
+	Password string `json:"password,omitempty" validate:"omitempty,min=8,max=72,password"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=admin user"`
+}
+
+func (r UpdateUserRequest) toEntity() *entity.User {
+	return &entity.User{
+		Email:    r.Email,
+		Name:     r.Name,
+		Password: r.Password,
+		Role:     r.Role,
+	}
+}
+
+// UserListResponse adalah envelope untuk satu halaman user
+type UserListResponse struct {
+	Items      []*entity.User `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	PrevCursor string         `json:"prev_cursor,omitempty"`
+	Total      *int64         `json:"total,omitempty"`
+}
+
+// ErrorResponse adalah body error RFC 7807 (application/problem+json) yang
+// ditulis oleh middleware.ErrorHandler
+type ErrorResponse = middleware.Problem
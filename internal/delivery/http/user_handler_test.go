@@ -0,0 +1,290 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/internal/middleware"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserUseCase adalah mock untuk UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) PatchUser(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) RestoreUser(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsers(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterface.Page[entity.User]), args.Error(1)
+}
+
+func (m *MockUserUseCase) VerifyCredentials(ctx context.Context, email, password string) (*entity.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	args := m.Called(ctx, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// setupUserRouter mendaftarkan handler tanpa middleware autentikasi agar
+// test fokus pada perilaku handler
+func setupUserRouter(userUseCase *MockUserUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := NewUserHandler(userUseCase, nil)
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.GET("/users/:id", h.GetUserByID)
+	router.PATCH("/users/:id", h.PatchUser)
+	router.DELETE("/users/:id", h.DeleteUser)
+	router.POST("/users/:id/restore", h.RestoreUser)
+	return router
+}
+
+func TestUserHandler_GetUserByID(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	mockUseCase.On("GetUserByID", mock.Anything, "test-id").Return(&entity.User{ID: "test-id", Email: "test@example.com"}, nil)
+	mockUseCase.On("GetUserByID", mock.Anything, "unknown-id").Return(nil, entity.ErrUserNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/test-id", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"test-id"`)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/unknown-id", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, middleware.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"code":"user_not_found"`)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserHandler_PatchUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	name := "Patched Name"
+	mockUseCase.On("PatchUser", mock.Anything, "test-id", entity.UserPatch{Name: &name}).
+		Return(&entity.User{ID: "test-id", Name: name}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/test-id", strings.NewReader(`{"name":"Patched Name"}`))
+	req.Header.Set("Content-Type", MergePatchContentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Patched Name"`)
+
+	mockUseCase.AssertExpectations(t)
+	mockUseCase.AssertNotCalled(t, "IsEmailTaken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_PatchUser_Invalid(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	mockUseCase.On("IsEmailTaken", mock.Anything, "taken@example.com", "test-id").Return(true, nil)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		code   string
+	}{
+		{"not an object", `["replace"]`, http.StatusBadRequest, "invalid_request_body"},
+		{"null field", `{"email":null}`, http.StatusUnprocessableEntity, "validation_failed"},
+		{"read only field", `{"id":"other-id"}`, http.StatusUnprocessableEntity, "validation_failed"},
+		{"weak password", `{"password":"weak"}`, http.StatusUnprocessableEntity, "validation_failed"},
+		{"duplicate email", `{"email":"taken@example.com"}`, http.StatusUnprocessableEntity, "validation_failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/users/test-id", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", MergePatchContentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"code":"`+tt.code+`"`)
+		})
+	}
+
+	mockUseCase.AssertNotCalled(t, "PatchUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_PatchUser_ForbiddenRole(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	// Aturan perubahan role ada di usecase; handler hanya meneruskan errornya
+	role := entity.RoleAdmin
+	mockUseCase.On("PatchUser", mock.Anything, "test-id", entity.UserPatch{Role: &role}).Return(nil, entity.ErrForbidden)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/test-id", strings.NewReader(`{"role":"admin"}`))
+	req.Header.Set("Content-Type", MergePatchContentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"forbidden"`)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserHandler_ETag(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	user := &entity.User{ID: "test-id", Email: "test@example.com", Version: 3}
+	name := "Patched Name"
+	mockUseCase.On("GetUserByID", mock.Anything, "test-id").Return(user, nil)
+	mockUseCase.On("PatchUser", mock.Anything, "test-id", entity.UserPatch{Name: &name, Version: 2}).
+		Return(nil, entity.ErrUserVersionConflict)
+	mockUseCase.On("PatchUser", mock.Anything, "test-id", entity.UserPatch{Name: &name, Version: 3}).
+		Return(&entity.User{ID: "test-id", Name: name, Version: 4}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/test-id", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+
+	// Representasi tidak berubah sejak ETag terakhir
+	req := httptest.NewRequest(http.MethodGet, "/users/test-id", nil)
+	req.Header.Set("If-None-Match", `"2", W/"3"`)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	patch := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/users/test-id", strings.NewReader(`{"name":"Patched Name"}`))
+		req.Header.Set("If-Match", ifMatch)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec = patch(`"2"`)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"precondition_failed"`)
+
+	rec = patch(`W/"3"`)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	rec = patch(`"3"`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	mockUseCase.On("DeleteUser", mock.Anything, "test-id").Return(nil)
+	mockUseCase.On("DeleteUser", mock.Anything, "unknown-id").Return(entity.ErrUserNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/test-id", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/unknown-id", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestUserHandler_RestoreUser(t *testing.T) {
+	mockUseCase := new(MockUserUseCase)
+	router := setupUserRouter(mockUseCase)
+
+	mockUseCase.On("RestoreUser", mock.Anything, "deleted-id").Return(&entity.User{ID: "deleted-id", Version: 3}, nil)
+	mockUseCase.On("RestoreUser", mock.Anything, "active-id").Return(nil, entity.ErrUserNotDeleted)
+	mockUseCase.On("RestoreUser", mock.Anything, "unknown-id").Return(nil, entity.ErrUserNotFound)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/deleted-id/restore", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	assert.NotContains(t, rec.Body.String(), "deleted_at")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/active-id/restore", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"user_not_deleted"`)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/unknown-id/restore", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockUseCase.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// MergePatchContentType adalah media type RFC 7396
+const MergePatchContentType = "application/merge-patch+json"
+
+// PatchUserRequest adalah hasil parsing merge patch untuk user. Field nil
+// berarti tidak dikirim dan tidak diubah.
+type PatchUserRequest struct {
+	Email    *string `json:"email,omitempty" validate:"omitnil,required,email,max=255"`
+	Name     *string `json:"name,omitempty" validate:"omitnil,required,max=255"`
+	Password *string `json:"password,omitempty" validate:"omitnil,min=8,max=72,password"`
+	Role     *string `json:"role,omitempty" validate:"omitnil,oneof=admin user"`
+}
+
+func (r PatchUserRequest) toPatch() entity.UserPatch {
+	return entity.UserPatch{
+		Email:    r.Email,
+		Name:     r.Name,
+		Password: r.Password,
+		Role:     r.Role,
+	}
+}
+
+// parseUserMergePatch membaca dokumen merge patch (RFC 7396). Member yang
+// tidak ada tidak diubah, sedangkan null berarti menghapus field; karena
+// semua field user wajib ada, null ditolak sebagai error validasi.
+func parseUserMergePatch(body []byte) (PatchUserRequest, error) {
+	var req PatchUserRequest
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil || doc == nil {
+		return req, apperror.Wrap(err, apperror.KindBadRequest, "invalid_request_body", "merge patch must be a JSON object")
+	}
+
+	targets := map[string]**string{
+		"email":    &req.Email,
+		"name":     &req.Name,
+		"password": &req.Password,
+		"role":     &req.Role,
+	}
+
+	names := make([]string, 0, len(doc))
+	for name := range doc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []apperror.FieldError
+	for _, name := range names {
+		raw := doc[name]
+		target, ok := targets[name]
+		if !ok {
+			fields = append(fields, apperror.FieldError{Field: name, Code: "not_patchable", Message: "cannot be changed"})
+			continue
+		}
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			fields = append(fields, apperror.FieldError{Field: name, Code: "required", Message: "cannot be removed"})
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			fields = append(fields, apperror.FieldError{Field: name, Code: "type", Message: "must be a string"})
+			continue
+		}
+		*target = &value
+	}
+
+	if len(fields) > 0 {
+		return req, apperror.Validation(fields)
+	}
+	return req, nil
+}
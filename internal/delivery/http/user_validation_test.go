@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/delivery/validation"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmailChecker menganggap email pada map sudah dipakai oleh ID terkait
+type fakeEmailChecker struct {
+	owners map[string]string
+	err    error
+}
+
+func (f fakeEmailChecker) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	owner, ok := f.owners[email]
+	return ok && owner != excludeID, nil
+}
+
+// validationFields mengambil daftar field dari error validasi
+func validationFields(t *testing.T, err error) []apperror.FieldError {
+	t.Helper()
+	require.Error(t, err)
+	appErr := apperror.From(err)
+	require.Equal(t, apperror.KindValidation, appErr.Kind)
+	fields, ok := appErr.Details["fields"].([]apperror.FieldError)
+	require.True(t, ok)
+	return fields
+}
+
+func TestUserRequestValidation_CreateUser(t *testing.T) {
+	v := validation.New(fakeEmailChecker{owners: map[string]string{"taken@example.com": "user-1"}})
+	ctx := context.Background()
+
+	req := CreateUserRequest{Email: "john@example.com", Name: "John", Password: "Secret123"}
+	require.NoError(t, v.User(ctx, &req, req.Email, ""))
+
+	req = CreateUserRequest{Email: "not-an-email", Password: "weakpass", Role: "root"}
+	err := v.User(ctx, &req, req.Email, "")
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Code: "email", Message: "must be a valid email address"},
+		{Field: "name", Code: "required", Message: "is required"},
+		{Field: "password", Code: "password", Message: "must contain at least one upper case letter, one lower case letter and one digit"},
+		{Field: "role", Code: "oneof", Message: "must be one of: admin, user"},
+	}, validationFields(t, err))
+
+	req = CreateUserRequest{Email: "taken@example.com", Name: "John", Password: "Short1"}
+	err = v.User(ctx, &req, req.Email, "")
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "password", Code: "min", Message: "must be at least 8 characters long"},
+		{Field: "email", Code: "unique_email", Message: "email is already registered"},
+	}, validationFields(t, err))
+}
+
+func TestUserRequestValidation_UpdateUser(t *testing.T) {
+	v := validation.New(fakeEmailChecker{owners: map[string]string{"taken@example.com": "user-1"}})
+	ctx := context.Background()
+
+	// Password boleh kosong dan email milik sendiri tidak dianggap bentrok
+	req := UpdateUserRequest{Email: "taken@example.com", Name: "John"}
+	require.NoError(t, v.User(ctx, &req, req.Email, "user-1"))
+
+	err := v.User(ctx, &req, req.Email, "user-2")
+	assert.Equal(t, "unique_email", validationFields(t, err)[0].Code)
+}
+
+func TestUserRequestValidation_EmailCheckError(t *testing.T) {
+	v := validation.New(fakeEmailChecker{err: errors.New("connection refused")})
+
+	req := CreateUserRequest{Email: "john@example.com", Name: "John", Password: "Secret123"}
+	err := v.User(context.Background(), &req, req.Email, "")
+	require.Error(t, err)
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+}
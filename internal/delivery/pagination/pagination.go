@@ -0,0 +1,46 @@
+package pagination
+
+import (
+	"errors"
+	"time"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+)
+
+// ErrQueryMismatch dikembalikan jika cursor yang valid dipakai dengan
+// filter, pencarian atau pengurutan yang berbeda dari saat cursor dibuat
+var ErrQueryMismatch = errors.New("cursor does not match the query")
+
+// payload adalah isi cursor (HTTP) dan page token (gRPC) yang dikirim ke
+// client dalam bentuk opaque dan ditandatangani. Query adalah
+// ListQuery.Fingerprint dari query yang menghasilkan cursor.
+type payload struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+	Backward  bool      `json:"b,omitempty"`
+	Query     string    `json:"q"`
+}
+
+// Encode mengubah cursor repository menjadi string opaque yang terikat pada
+// query. Cursor nil menghasilkan string kosong.
+func Encode(codec *cursor.Codec, c *repoInterface.Cursor, query repoInterface.ListQuery) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	return codec.Encode(payload{CreatedAt: c.CreatedAt, ID: c.ID, Backward: c.Backward, Query: query.Fingerprint()})
+}
+
+// Decode memverifikasi token dan memastikan token dibuat untuk query yang
+// sama. Token yang rusak atau diubah menghasilkan cursor.ErrInvalidCursor,
+// token untuk query lain menghasilkan ErrQueryMismatch.
+func Decode(codec *cursor.Codec, token string, query repoInterface.ListQuery) (*repoInterface.Cursor, error) {
+	var p payload
+	if err := codec.Decode(token, &p); err != nil {
+		return nil, err
+	}
+	if p.Query != query.Fingerprint() {
+		return nil, ErrQueryMismatch
+	}
+	return &repoInterface.Cursor{CreatedAt: p.CreatedAt, ID: p.ID, Backward: p.Backward}, nil
+}
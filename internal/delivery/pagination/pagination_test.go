@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/cursor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	codec, err := cursor.NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	query := repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{
+			{Field: "email", Operator: repoInterface.OpPrefix, Value: "john"},
+			{Field: "name", Operator: repoInterface.OpContains, Value: "doe"},
+		},
+		Search: "smith",
+		Limit:  10,
+	}
+	next := &repoInterface.Cursor{CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 123456000, time.UTC), ID: "user-id", Backward: true}
+
+	token, err := Encode(codec, next, query)
+	require.NoError(t, err)
+	assert.NotContains(t, token, "user-id")
+
+	// Urutan filter, offset dan limit tidak berpengaruh
+	same := query
+	same.Filters = []repoInterface.Filter{query.Filters[1], query.Filters[0]}
+	same.Limit = 50
+	same.Offset = 20
+	decoded, err := Decode(codec, token, same)
+	require.NoError(t, err)
+	assert.True(t, next.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, "user-id", decoded.ID)
+	assert.True(t, decoded.Backward)
+
+	empty, err := Encode(codec, nil, query)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestDecode_RejectsDifferentQuery(t *testing.T) {
+	codec, err := cursor.NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	query := repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpPrefix, Value: "john"}},
+		Search:  "smith",
+	}
+	token, err := Encode(codec, &repoInterface.Cursor{ID: "user-id"}, query)
+	require.NoError(t, err)
+
+	changes := map[string]func(q *repoInterface.ListQuery){
+		"filter value": func(q *repoInterface.ListQuery) {
+			q.Filters = []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpPrefix, Value: "jane"}}
+		},
+		"filter operator": func(q *repoInterface.ListQuery) {
+			q.Filters = []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpEq, Value: "john"}}
+		},
+		"no filter":       func(q *repoInterface.ListQuery) { q.Filters = nil },
+		"search":          func(q *repoInterface.ListQuery) { q.Search = "doe" },
+		"sort":            func(q *repoInterface.ListQuery) { q.Sort = []repoInterface.SortField{{Field: "name"}} },
+		"include deleted": func(q *repoInterface.ListQuery) { q.IncludeDeleted = true },
+	}
+	for name, change := range changes {
+		other := query
+		change(&other)
+		_, err := Decode(codec, token, other)
+		assert.ErrorIs(t, err, ErrQueryMismatch, name)
+	}
+
+	_, err = Decode(codec, token+"x", query)
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+}
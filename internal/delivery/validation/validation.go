@@ -0,0 +1,153 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// EmailChecker dipakai untuk pengecekan awal keunikan email sebelum data
+// dikirim ke usecase
+type EmailChecker interface {
+	IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error)
+}
+
+// Validator memvalidasi input dari HTTP dan command line dengan tag validate
+// yang sama, lalu menerjemahkan error menjadi apperror.FieldError. Nama field
+// pada error mengikuti tag json agar sama dengan payload yang dikirim client.
+type Validator struct {
+	validate *validator.Validate
+	emails   EmailChecker
+}
+
+// New membuat instance baru dari Validator
+func New(emails EmailChecker) *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	RegisterRules(validate)
+
+	return &Validator{
+		validate: validate,
+		emails:   emails,
+	}
+}
+
+// RegisterRules mendaftarkan aturan tambahan yang dipakai oleh input user,
+// yaitu tag password untuk entity.IsStrongPassword
+func RegisterRules(validate *validator.Validate) {
+	// Registrasi hanya gagal jika tag kosong atau fungsi nil
+	_ = validate.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return entity.IsStrongPassword(fl.Field().String())
+	})
+}
+
+// Struct memvalidasi req dan mengembalikan apperror.Validation jika ada
+// field yang gagal
+func (v *Validator) Struct(req any) error {
+	fields, err := FieldErrors(v.validate.Struct(req))
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return apperror.Validation(fields)
+	}
+	return nil
+}
+
+// User memvalidasi input user beserta keunikan email. excludeID diisi
+// dengan ID user yang sedang diupdate. Keunikan email hanya diperiksa jika
+// email dikirim dan formatnya valid; constraint di database tetap menjadi
+// penentu akhir.
+func (v *Validator) User(ctx context.Context, req any, email, excludeID string) error {
+	fields, err := FieldErrors(v.validate.Struct(req))
+	if err != nil {
+		return err
+	}
+
+	if email != "" && !hasFieldError(fields, "email") {
+		taken, err := v.emails.IsEmailTaken(ctx, email, excludeID)
+		if err != nil {
+			return fmt.Errorf("error checking email: %w", err)
+		}
+		if taken {
+			fields = append(fields, apperror.FieldError{
+				Field:   "email",
+				Code:    "unique_email",
+				Message: "email is already registered",
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperror.Validation(fields)
+	}
+	return nil
+}
+
+// FieldErrors menerjemahkan error dari validator menjadi daftar field yang
+// gagal. Error selain validator.ValidationErrors dikembalikan sebagai error.
+func FieldErrors(err error) ([]apperror.FieldError, error) {
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, fmt.Errorf("error validating request: %w", err)
+	}
+
+	fields := make([]apperror.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, apperror.FieldError{
+			Field:   fe.Field(),
+			Code:    fe.Tag(),
+			Message: Message(fe),
+		})
+	}
+	return fields, nil
+}
+
+// Message mengembalikan pesan untuk satu field yang gagal validasi
+func Message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return fmt.Sprintf("must be at least %s characters long", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s characters long", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "password":
+		return "must contain at least one upper case letter, one lower case letter and one digit"
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed on the %q rule", fe.Tag())
+	}
+}
+
+func hasFieldError(fields []apperror.FieldError, field string) bool {
+	for _, f := range fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type takenEmails map[string]bool
+
+func (t takenEmails) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	return t[email], nil
+}
+
+type testInput struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password,omitempty" validate:"omitempty,min=8,max=72,password"`
+	Limit    int    `json:"limit" validate:"gte=0,lte=100"`
+}
+
+func fieldsOf(t *testing.T, err error) []apperror.FieldError {
+	t.Helper()
+	require.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+	fields, ok := apperror.From(err).Details["fields"].([]apperror.FieldError)
+	require.True(t, ok)
+	return fields
+}
+
+func TestValidator_Struct(t *testing.T) {
+	v := New(takenEmails{})
+
+	require.NoError(t, v.Struct(&testInput{Email: "john@example.com", Password: "Secret123"}))
+
+	err := v.Struct(&testInput{Email: "", Password: "lowercase1", Limit: 101})
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Code: "required", Message: "is required"},
+		{Field: "password", Code: "password", Message: "must contain at least one upper case letter, one lower case letter and one digit"},
+		{Field: "limit", Code: "lte", Message: "must be less than or equal to 100"},
+	}, fieldsOf(t, err))
+
+	// Error selain kesalahan validasi bukan error validasi
+	err = v.Struct(nil)
+	require.Error(t, err)
+	assert.Equal(t, apperror.KindInternal, apperror.KindOf(err))
+}
+
+func TestValidator_User(t *testing.T) {
+	v := New(takenEmails{"taken@example.com": true})
+	ctx := context.Background()
+
+	input := testInput{Email: "taken@example.com"}
+	err := v.User(ctx, &input, input.Email, "")
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Code: "unique_email", Message: "email is already registered"},
+	}, fieldsOf(t, err))
+
+	// Email yang tidak valid tidak diperiksa keunikannya
+	input = testInput{Email: "taken@"}
+	err = v.User(ctx, &input, input.Email, "")
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Code: "email", Message: "must be a valid email address"},
+	}, fieldsOf(t, err))
+}
+
+func TestFieldErrors(t *testing.T) {
+	fields, err := FieldErrors(nil)
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+
+	_, err = FieldErrors(errors.New("boom"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Kind adalah kategori error yang menentukan status code pada setiap transport
+type Kind string
+
+const (
+	KindBadRequest         Kind = "bad_request"
+	KindValidation         Kind = "validation"
+	KindUnauthorized       Kind = "unauthorized"
+	KindForbidden          Kind = "forbidden"
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindInternal           Kind = "internal"
+)
+
+// Error adalah error domain yang aman untuk dikirim ke client. Message dan
+// Details ditujukan untuk client, sedangkan cause hanya untuk log.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Details map[string]any
+	cause   error
+}
+
+// FieldError menjelaskan satu field yang gagal validasi
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// New membuat error domain baru
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap membuat error domain baru dengan cause yang tetap dapat diperiksa
+// melalui errors.Is dan errors.As
+func Wrap(err error, kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message, cause: err}
+}
+
+// BadRequest membuat error untuk request yang tidak dapat dibaca
+func BadRequest(code, message string) *Error {
+	return New(KindBadRequest, code, message)
+}
+
+// Validation membuat error validasi beserta daftar field yang gagal
+func Validation(fields []FieldError) *Error {
+	return New(KindValidation, "validation_failed", "validation failed").
+		WithDetail("fields", fields)
+}
+
+// Unauthorized membuat error untuk request yang belum terautentikasi
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// Forbidden membuat error untuk request yang tidak memiliki akses
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// NotFound membuat error untuk resource yang tidak ditemukan
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict membuat error untuk perubahan yang bentrok dengan data lain
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// PreconditionFailed membuat error untuk precondition dari client, misalnya
+// header If-Match, yang tidak terpenuhi
+func PreconditionFailed(code, message string) *Error {
+	return New(KindPreconditionFailed, code, message)
+}
+
+// Internal membungkus error yang tidak boleh diperlihatkan ke client
+func Internal(err error) *Error {
+	return Wrap(err, KindInternal, "internal_error", "internal server error")
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.cause)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is menganggap dua error domain sama jika Kind dan Code-nya sama, sehingga
+// errors.Is(err, entity.ErrUserNotFound) tetap berlaku setelah WithDetail
+// atau WithCause
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind && e.Code == t.Code
+}
+
+// WithDetail mengembalikan salinan error dengan detail tambahan
+func (e *Error) WithDetail(key string, value any) *Error {
+	clone := *e
+	clone.Details = make(map[string]any, len(e.Details)+1)
+	for k, v := range e.Details {
+		clone.Details[k] = v
+	}
+	clone.Details[key] = value
+	return &clone
+}
+
+// WithCause mengembalikan salinan error dengan cause yang dibungkus
+func (e *Error) WithCause(err error) *Error {
+	clone := *e
+	clone.cause = err
+	return &clone
+}
+
+// From mengambil error domain dari rantai error. Error yang tidak dikenal
+// dibungkus sebagai Internal agar detailnya tidak bocor ke client.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
+
+// KindOf mengembalikan Kind dari err, atau KindInternal jika err bukan error domain
+func KindOf(err error) Kind {
+	return From(err).Kind
+}
+
+// HTTPStatus memetakan Kind ke status code HTTP
+func HTTPStatus(kind Kind) int {
+	switch kind {
+	case KindBadRequest:
+		return http.StatusBadRequest
+	case KindValidation:
+		return http.StatusUnprocessableEntity
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// conflictGRPCCodes memetakan Code dari error KindConflict yang tidak dapat
+// diselesaikan dengan mengulang request. Konflik lain, misalnya
+// version_conflict dan concurrent_update, dipetakan ke Aborted.
+var conflictGRPCCodes = map[string]codes.Code{
+	"already_exists":       codes.AlreadyExists,
+	"email_already_exists": codes.AlreadyExists,
+	"invalid_reference":    codes.FailedPrecondition,
+	"user_not_deleted":     codes.FailedPrecondition,
+}
+
+// GRPCCode memetakan error ke status code gRPC. Selain dari Kind, status
+// untuk KindConflict ditentukan oleh Code: hanya duplikasi data yang menjadi
+// AlreadyExists, sedangkan konflik karena perubahan bersamaan menjadi
+// Aborted agar client tahu request dapat diulang.
+func (e *Error) GRPCCode() codes.Code {
+	if e.Kind == KindConflict {
+		if code, ok := conflictGRPCCodes[e.Code]; ok {
+			return code
+		}
+	}
+	return GRPCCode(e.Kind)
+}
+
+// GRPCCode memetakan Kind ke status code gRPC. KindConflict dipetakan ke
+// Aborted; gunakan Error.GRPCCode untuk pemetaan berdasarkan Code.
+func GRPCCode(kind Kind) codes.Code {
+	switch kind {
+	case KindBadRequest, KindValidation:
+		return codes.InvalidArgument
+	case KindUnauthorized:
+		return codes.Unauthenticated
+	case KindForbidden:
+		return codes.PermissionDenied
+	case KindNotFound:
+		return codes.NotFound
+	case KindConflict:
+		return codes.Aborted
+	case KindPreconditionFailed:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,82 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestError_IsAndFrom(t *testing.T) {
+	errNotFound := NotFound("user_not_found", "user not found")
+	cause := errors.New("sql: no rows in result set")
+
+	wrapped := fmt.Errorf("error getting user: %w", errNotFound.WithCause(cause))
+	assert.ErrorIs(t, wrapped, errNotFound)
+	assert.ErrorIs(t, wrapped, cause)
+	assert.NotErrorIs(t, wrapped, NotFound("role_not_found", "role not found"))
+
+	appErr := From(wrapped)
+	assert.Equal(t, KindNotFound, appErr.Kind)
+	assert.Equal(t, "user not found", appErr.Message)
+
+	// Detail tidak mengubah error aslinya
+	withDetail := errNotFound.WithDetail("id", "123")
+	assert.Equal(t, "123", withDetail.Details["id"])
+	assert.Nil(t, errNotFound.Details)
+	assert.ErrorIs(t, withDetail, errNotFound)
+}
+
+func TestFrom_UnknownErrorIsInternal(t *testing.T) {
+	appErr := From(errors.New(`pq: duplicate key value violates unique constraint "users_email_key"`))
+	assert.Equal(t, KindInternal, appErr.Kind)
+	assert.Equal(t, "internal server error", appErr.Message)
+	assert.Contains(t, appErr.Error(), "users_email_key")
+}
+
+func TestStatusMapping(t *testing.T) {
+	tests := []struct {
+		kind   Kind
+		status int
+		code   codes.Code
+	}{
+		{KindBadRequest, http.StatusBadRequest, codes.InvalidArgument},
+		{KindValidation, http.StatusUnprocessableEntity, codes.InvalidArgument},
+		{KindUnauthorized, http.StatusUnauthorized, codes.Unauthenticated},
+		{KindForbidden, http.StatusForbidden, codes.PermissionDenied},
+		{KindNotFound, http.StatusNotFound, codes.NotFound},
+		{KindConflict, http.StatusConflict, codes.Aborted},
+		{KindPreconditionFailed, http.StatusPreconditionFailed, codes.FailedPrecondition},
+		{KindInternal, http.StatusInternalServerError, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.status, HTTPStatus(tt.kind), tt.kind)
+		assert.Equal(t, tt.code, GRPCCode(tt.kind), tt.kind)
+	}
+}
+
+func TestError_GRPCCode(t *testing.T) {
+	tests := []struct {
+		err  *Error
+		code codes.Code
+	}{
+		{Conflict("already_exists", "resource already exists"), codes.AlreadyExists},
+		{Conflict("email_already_exists", "email is already registered"), codes.AlreadyExists},
+		{Conflict("invalid_reference", "resource references another resource"), codes.FailedPrecondition},
+		{Conflict("user_not_deleted", "user has not been deleted"), codes.FailedPrecondition},
+		{Conflict("version_conflict", "user has been modified by another request"), codes.Aborted},
+		{Conflict("concurrent_update", "resource was modified concurrently, please retry"), codes.Aborted},
+		{Conflict("unknown_conflict", "conflict"), codes.Aborted},
+		// Code hanya berpengaruh pada KindConflict
+		{NotFound("already_exists", "not found"), codes.NotFound},
+		{PreconditionFailed("precondition_failed", "resource has changed"), codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, tt.err.GRPCCode(), tt.err.Code)
+	}
+}
@@ -1,7 +1,17 @@
 package entity
 
-import "errors"
+import "github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound        = apperror.NotFound("user_not_found", "user not found")
+	ErrEmailAlreadyExists  = apperror.Conflict("email_already_exists", "email is already registered")
+	ErrUserVersionConflict = apperror.Conflict("version_conflict", "user has been modified by another request")
+	ErrUserNotDeleted      = apperror.Conflict("user_not_deleted", "user has not been deleted")
+	ErrInvalidCredentials  = apperror.Unauthorized("invalid_credentials", "invalid email or password")
+
+	ErrInvalidRefreshToken  = apperror.Unauthorized("invalid_refresh_token", "invalid or expired refresh token")
+	ErrRefreshTokenReused   = apperror.Unauthorized("refresh_token_reused", "refresh token reuse detected")
+	ErrRefreshTokenNotFound = apperror.NotFound("refresh_token_not_found", "refresh token not found")
+
+	ErrForbidden = apperror.Forbidden("forbidden", "you do not have permission to perform this action")
 )
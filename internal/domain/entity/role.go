@@ -0,0 +1,18 @@
+package entity
+
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+
+	// DefaultRole adalah role yang diberikan kepada user baru
+	DefaultRole = RoleUser
+)
+
+const (
+	PermissionUsersCreate  = "users:create"
+	PermissionUsersRead    = "users:read"
+	PermissionUsersList    = "users:list"
+	PermissionUsersUpdate  = "users:update"
+	PermissionUsersDelete  = "users:delete"
+	PermissionUsersRestore = "users:restore"
+)
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+)
+
+// RefreshToken merepresentasikan refresh token yang diterbitkan untuk user.
+// Token mentah tidak pernah disimpan, hanya hash SHA-256-nya. Semua token
+// hasil rotasi dari satu login berbagi FamilyID yang sama.
+type RefreshToken struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	ReplacedBy string     `json:"replaced_by,omitempty" db:"replaced_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsRevoked bernilai true jika token sudah dicabut atau dirotasi
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsRotated bernilai true jika token sudah diganti token baru melalui
+// refresh. Token yang dicabut karena logout tidak memiliki pengganti.
+func (t *RefreshToken) IsRotated() bool {
+	return t.ReplacedBy != ""
+}
+
+// IsExpired bernilai true jika token sudah melewati masa berlakunya
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// TokenPair adalah pasangan access token dan refresh token hasil login atau refresh
+type TokenPair struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
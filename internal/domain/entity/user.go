@@ -2,14 +2,53 @@ package entity
 
 import (
 	"time"
+	"unicode"
 )
 
-// User merepresentasikan entitas pengguna dalam sistem
+// User merepresentasikan entitas pengguna dalam sistem. Version bertambah
+// setiap kali user diubah dan dipakai untuk optimistic locking. DeletedAt
+// terisi jika user sudah dihapus (soft delete).
 type User struct {
-	ID        string    `json:"id" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	Name      string    `json:"name" db:"name"`
-	Password  string    `json:"-" db:"password"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        string     `json:"id" db:"id"`
+	Email     string     `json:"email" db:"email"`
+	Name      string     `json:"name" db:"name"`
+	Password  string     `json:"-" db:"password"`
+	Role      string     `json:"role" db:"role"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	Version   int        `json:"version" db:"version"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// UserPatch berisi perubahan parsial pada user. Field bernilai nil tidak diubah.
+// Version tidak nol berarti patch hanya diterapkan pada versi tersebut.
+type UserPatch struct {
+	Email     *string
+	Name      *string
+	Password  *string
+	Role      *string
+	UpdatedAt time.Time
+	Version   int
+}
+
+// IsEmpty bernilai true jika tidak ada field yang diubah
+func (p UserPatch) IsEmpty() bool {
+	return p.Email == nil && p.Name == nil && p.Password == nil && p.Role == nil
+}
+
+// IsStrongPassword memastikan password mengandung huruf besar, huruf kecil
+// dan angka. Panjang password divalidasi terpisah oleh setiap delivery.
+func IsStrongPassword(password string) bool {
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasUpper && hasLower && hasDigit
 }
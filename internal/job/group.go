@@ -0,0 +1,52 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group menjalankan background job dengan context bersama dan menunggu
+// semua job selesai saat dihentikan, sehingga job tidak lagi memakai
+// dependency seperti koneksi database yang ditutup setelahnya
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup membuat instance baru dari Group. Job menerima context turunan
+// ctx yang dibatalkan oleh Stop.
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go menjalankan fn di goroutine baru. fn harus kembali setelah context-nya
+// dibatalkan.
+func (g *Group) Go(fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g.ctx)
+	}()
+}
+
+// Stop membatalkan context job lalu menunggu semua job selesai, dibatasi
+// oleh ctx
+func (g *Group) Stop(ctx context.Context) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for background jobs: %w", ctx.Err())
+	}
+}
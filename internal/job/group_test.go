@@ -0,0 +1,39 @@
+package job
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Stop(t *testing.T) {
+	group := NewGroup(context.Background())
+
+	var finished atomic.Bool
+	group.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		// Job masih membersihkan resource setelah context dibatalkan
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+	})
+
+	require.NoError(t, group.Stop(context.Background()))
+	assert.True(t, finished.Load(), "Stop must wait for running jobs")
+}
+
+func TestGroup_StopTimeout(t *testing.T) {
+	group := NewGroup(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+	group.Go(func(ctx context.Context) { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := group.Stop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
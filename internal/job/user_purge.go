@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// userPurger adalah bagian dari UserUseCase yang dibutuhkan oleh UserPurgeJob
+type userPurger interface {
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// UserPurgeJob menghapus permanen user yang sudah di-soft delete lebih lama
+// dari retention secara berkala
+type UserPurgeJob struct {
+	purger    userPurger
+	retention time.Duration
+	interval  time.Duration
+}
+
+// defaultPurgeInterval dipakai jika interval yang diberikan tidak valid
+const defaultPurgeInterval = time.Hour
+
+// NewUserPurgeJob membuat instance baru dari UserPurgeJob
+func NewUserPurgeJob(purger userPurger, retention, interval time.Duration) *UserPurgeJob {
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	return &UserPurgeJob{
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run menjalankan purge sekali saat start lalu setiap interval sampai ctx
+// dibatalkan
+func (j *UserPurgeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := j.RunOnce(ctx); err != nil {
+			logger.FromContext(ctx).Error("error purging deleted users", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce menjalankan satu kali purge dan mengembalikan jumlah user yang
+// dihapus permanen
+func (j *UserPurgeJob) RunOnce(ctx context.Context) (int64, error) {
+	purged, err := j.purger.PurgeDeletedUsers(ctx, j.retention)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		logger.FromContext(ctx).Info("purged deleted users",
+			zap.Int64("count", purged),
+			zap.Duration("retention", j.retention),
+		)
+	}
+	return purged, nil
+}
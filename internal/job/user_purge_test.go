@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePurger struct {
+	mu         sync.Mutex
+	calls      int
+	retentions []time.Duration
+	err        error
+}
+
+func (f *fakePurger) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	f.retentions = append(f.retentions, retention)
+	if f.err != nil {
+		return 0, f.err
+	}
+	return 2, nil
+}
+
+func (f *fakePurger) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestUserPurgeJob_RunOnce(t *testing.T) {
+	purger := &fakePurger{}
+	job := NewUserPurgeJob(purger, 30*24*time.Hour, time.Hour)
+
+	purged, err := job.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.Equal(t, []time.Duration{30 * 24 * time.Hour}, purger.retentions)
+
+	purger.err = errors.New("database down")
+	_, err = job.RunOnce(context.Background())
+	assert.Error(t, err)
+}
+
+func TestUserPurgeJob_Run(t *testing.T) {
+	purger := &fakePurger{}
+	job := NewUserPurgeJob(purger, time.Hour, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return purger.callCount() >= 2 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context was cancelled")
+	}
+}
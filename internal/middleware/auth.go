@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PrincipalKey adalah key gin.Context untuk principal yang terautentikasi
+const PrincipalKey = "principal"
+
+// Authenticate memverifikasi bearer token pada header Authorization dan
+// menyimpan principal ke gin.Context serta context milik request
+func Authenticate(tokenManager *auth.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := auth.ExtractBearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+		authenticate(c, tokenManager, token)
+	}
+}
+
+// OptionalAuthenticate sama seperti Authenticate, tetapi request tanpa header
+// Authorization tetap diteruskan sebagai anonim. Token yang tidak valid tetap
+// ditolak dengan 401.
+func OptionalAuthenticate(tokenManager *auth.TokenManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		token, err := auth.ExtractBearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+		authenticate(c, tokenManager, token)
+	}
+}
+
+func authenticate(c *gin.Context, tokenManager *auth.TokenManager, token string) {
+	principal, err := tokenManager.ParseAccessToken(token)
+	if err != nil {
+		abortUnauthorized(c, auth.ErrInvalidToken)
+		return
+	}
+
+	c.Set(PrincipalKey, principal)
+	ctx := auth.NewContext(c.Request.Context(), principal)
+	ctx = logger.With(ctx, zap.String("user_id", principal.UserID))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// GetPrincipal mengambil principal dari gin.Context
+func GetPrincipal(c *gin.Context) (*auth.Principal, bool) {
+	return auth.FromContext(c.Request.Context())
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	abortWithError(c, err)
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// RequirePermission menolak request dengan 403 jika principal tidak memiliki
+// permission yang diminta. Harus dipasang setelah Authenticate.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthorized(c, auth.ErrMissingToken)
+			return
+		}
+		if !principal.HasPermission(permission) {
+			abortWithError(c, entity.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelfOrAdmin hanya mengizinkan admin atau pemilik record yang ID-nya
+// ada pada path parameter param. Harus dipasang setelah Authenticate.
+func RequireSelfOrAdmin(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthorized(c, auth.ErrMissingToken)
+			return
+		}
+		if !principal.CanAccessUser(c.Param(param)) {
+			abortWithError(c, entity.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
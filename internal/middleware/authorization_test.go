@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAuthorizationRouter(t *testing.T) (*gin.Engine, *auth.TokenManager) {
+	gin.SetMode(gin.TestMode)
+
+	tokenManager, err := auth.NewTokenManager(config.AuthConfig{
+		JWTAlgorithm:    auth.AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+	require.NoError(t, err)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	router := gin.New()
+	users := router.Group("/users", Authenticate(tokenManager))
+	users.GET("/:id", RequirePermission(entity.PermissionUsersRead), RequireSelfOrAdmin("id"), ok)
+	users.DELETE("/:id", RequirePermission(entity.PermissionUsersDelete), ok)
+
+	return router, tokenManager
+}
+
+func issueToken(t *testing.T, tokenManager *auth.TokenManager, user *entity.User, permissions ...string) string {
+	token, _, err := tokenManager.IssueAccessToken(user, permissions)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthorization(t *testing.T) {
+	router, tokenManager := setupAuthorizationRouter(t)
+
+	admin := issueToken(t, tokenManager, &entity.User{ID: "admin-id", Role: entity.RoleAdmin},
+		entity.PermissionUsersRead, entity.PermissionUsersDelete)
+	user := issueToken(t, tokenManager, &entity.User{ID: "user-id", Role: entity.RoleUser},
+		entity.PermissionUsersRead)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"anonymous", http.MethodGet, "/users/user-id", "", http.StatusUnauthorized},
+		{"invalid token", http.MethodGet, "/users/user-id", "invalid", http.StatusUnauthorized},
+		{"user reads self", http.MethodGet, "/users/user-id", user, http.StatusOK},
+		{"user reads other", http.MethodGet, "/users/other-id", user, http.StatusForbidden},
+		{"admin reads other", http.MethodGet, "/users/other-id", admin, http.StatusOK},
+		{"user deletes", http.MethodDelete, "/users/user-id", user, http.StatusForbidden},
+		{"admin deletes", http.MethodDelete, "/users/other-id", admin, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusForbidden {
+				assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{
+					"type": "about:blank",
+					"title": "Forbidden",
+					"status": 403,
+					"detail": "`+entity.ErrForbidden.Message+`",
+					"instance": "`+tt.path+`",
+					"code": "forbidden"
+				}`, rec.Body.String())
+			}
+		})
+	}
+}
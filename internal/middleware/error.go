@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"go.uber.org/zap"
+)
+
+// ProblemContentType adalah media type RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// Problem adalah body error RFC 7807. Details dari error domain dikirim
+// sebagai extension member, misalnya "fields" pada error validasi.
+// RequestID memudahkan client melaporkan error yang dapat dicari di log.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	Code      string         `json:"code"`
+	RequestID string         `json:"request_id,omitempty"`
+	Details   map[string]any `json:"-"`
+}
+
+// MarshalJSON menggabungkan Details ke level teratas body
+func (p Problem) MarshalJSON() ([]byte, error) {
+	body := make(map[string]any, len(p.Details)+7)
+	for k, v := range p.Details {
+		body[k] = v
+	}
+	body["type"] = p.Type
+	body["title"] = p.Title
+	body["status"] = p.Status
+	body["code"] = p.Code
+	if p.Detail != "" {
+		body["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		body["instance"] = p.Instance
+	}
+	if p.RequestID != "" {
+		body["request_id"] = p.RequestID
+	}
+	return json.Marshal(body)
+}
+
+// ErrorHandler mengubah error yang dicatat handler melalui c.Error menjadi
+// response problem+json. Handler cukup memanggil c.Error(err) lalu return.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		writeProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// NewProblem membangun Problem dari err. Error yang bukan error domain
+// diperlakukan sebagai internal error tanpa membocorkan pesan aslinya.
+func NewProblem(err error, instance string) Problem {
+	appErr := apperror.From(err)
+	status := apperror.HTTPStatus(appErr.Kind)
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: instance,
+		Code:     appErr.Code,
+		Details:  appErr.Details,
+	}
+}
+
+func writeProblem(c *gin.Context, err error) {
+	problem := NewProblem(err, c.Request.URL.Path)
+	problem.RequestID, _ = requestid.FromContext(c.Request.Context())
+	log := logger.FromContext(c.Request.Context())
+	if problem.Status >= http.StatusInternalServerError {
+		log.Error("internal error", zap.Error(err))
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.Status(problem.Status)
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		log.Error("error encoding problem response", zap.Error(marshalErr))
+		return
+	}
+	_, _ = c.Writer.Write(body)
+}
+
+// abortWithError menghentikan request dengan response problem+json
+func abortWithError(c *gin.Context, err error) {
+	writeProblem(c, err)
+	c.Abort()
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/not-found", func(c *gin.Context) {
+		_ = c.Error(entity.ErrUserNotFound)
+	})
+	router.GET("/validation", func(c *gin.Context) {
+		_ = c.Error(apperror.Validation([]apperror.FieldError{
+			{Field: "email", Code: "email", Message: "must be a valid email address"},
+		}))
+	})
+	router.GET("/internal", func(c *gin.Context) {
+		_ = c.Error(errors.New(`pq: relation "users" does not exist`))
+	})
+	router.GET("/ok", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{
+			path:   "/not-found",
+			status: http.StatusNotFound,
+			body:   `{"type":"about:blank","title":"Not Found","status":404,"detail":"user not found","instance":"/not-found","code":"user_not_found"}`,
+		},
+		{
+			path:   "/validation",
+			status: http.StatusUnprocessableEntity,
+			body: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"validation failed","instance":"/validation","code":"validation_failed",
+				"fields":[{"field":"email","code":"email","message":"must be a valid email address"}]}`,
+		},
+		{
+			path:   "/internal",
+			status: http.StatusInternalServerError,
+			body:   `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal server error","instance":"/internal","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.body, rec.Body.String())
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
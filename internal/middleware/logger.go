@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// AccessLog menyimpan logger milik request ke context, sehingga usecase dan
+// repository dapat memanggil logger.FromContext(ctx), lalu menulis satu baris
+// access log setelah request selesai. Dipasang setelah RequestID dan
+// middleware tracing agar log membawa request_id dan trace_id.
+func AccessLog(log *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestLog := log
+		if requestID, ok := requestid.FromContext(c.Request.Context()); ok {
+			requestLog = requestLog.With(zap.String("request_id", requestID))
+		}
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			requestLog = requestLog.With(zap.String("trace_id", spanContext.TraceID().String()))
+		}
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), requestLog))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		fields := []zap.Field{
+			zap.String("http.method", c.Request.Method),
+			zap.String("http.route", route),
+			zap.String("http.path", c.Request.URL.Path),
+			zap.Int("http.status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.Int("response_size", c.Writer.Size()),
+		}
+		if principal, ok := auth.FromContext(c.Request.Context()); ok {
+			fields = append(fields, zap.String("user_id", principal.UserID))
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.Error(c.Errors.Last().Err))
+		}
+
+		requestLog.Log(accessLogLevel(status), "http request", fields...)
+	}
+}
+
+// Recovery mengubah panic pada handler menjadi response 500 problem+json dan
+// mencatat panic beserta stack trace ke logger milik request
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(c.Request.Context()).Error("panic recovered",
+					zap.Any("panic", r),
+					zap.Stack("stack"),
+				)
+				abortWithError(c, apperror.Internal(fmt.Errorf("panic: %v", r)))
+			}
+		}()
+		c.Next()
+	}
+}
+
+func accessLogLevel(status int) zapcore.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return zapcore.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
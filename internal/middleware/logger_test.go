@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	router := gin.New()
+	router.Use(RequestID(), AccessLog(zap.New(core)), Recovery(), ErrorHandler())
+	router.GET("/users/:id", func(c *gin.Context) {
+		principal := &auth.Principal{UserID: "user-1"}
+		c.Request = c.Request.WithContext(auth.NewContext(c.Request.Context(), principal))
+		logger.FromContext(c.Request.Context()).Debug("from handler")
+		_ = c.Error(entity.ErrUserNotFound)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set(requestid.Header, "req-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Log dari handler membawa request id milik request
+	handlerLogs := logs.FilterMessage("from handler").All()
+	require.Len(t, handlerLogs, 1)
+	assert.Equal(t, "req-1", handlerLogs[0].ContextMap()["request_id"])
+
+	accessLogs := logs.FilterMessage("http request").All()
+	require.Len(t, accessLogs, 1)
+	assert.Equal(t, zapcore.WarnLevel, accessLogs[0].Level)
+	fields := accessLogs[0].ContextMap()
+	assert.Equal(t, "GET", fields["http.method"])
+	assert.Equal(t, "/users/:id", fields["http.route"])
+	assert.Equal(t, "/users/42", fields["http.path"])
+	assert.EqualValues(t, http.StatusNotFound, fields["http.status"])
+	assert.Equal(t, "req-1", fields["request_id"])
+	assert.Equal(t, "user-1", fields["user_id"])
+	assert.Contains(t, fields, "latency")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, 1, logs.FilterMessage("panic recovered").Len())
+
+	accessLogs = logs.FilterMessage("http request").All()
+	require.Len(t, accessLogs, 2)
+	assert.Equal(t, zapcore.ErrorLevel, accessLogs[1].Level)
+}
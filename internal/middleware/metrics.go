@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/pkg/metrics"
+)
+
+// Metrics mencatat jumlah dan durasi request HTTP per method, route template
+// dan status code. Request ke route yang tidak terdaftar dikelompokkan
+// sebagai "unmatched" agar path acak tidak menambah time series.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveHTTP(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+)
+
+// RequestID memakai header X-Request-ID dari client jika valid atau membuat
+// id baru, lalu menyimpannya ke context milik request dan mengirimkannya
+// kembali pada header response. Harus dipasang sebelum AccessLog.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := requestid.Resolve(c.GetHeader(requestid.Header))
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(RequestID(), ErrorHandler())
+	router.GET("/ok", func(c *gin.Context) {
+		id, _ := requestid.FromContext(c.Request.Context())
+		c.String(http.StatusOK, id)
+	})
+	router.GET("/not-found", func(c *gin.Context) {
+		_ = c.Error(entity.ErrUserNotFound)
+	})
+
+	// Id dari client dipakai ulang
+	req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(requestid.Header, "client-id-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, "client-id-1", rec.Header().Get(requestid.Header))
+	assert.Equal(t, "client-id-1", rec.Body.String())
+
+	// Id yang tidak valid diganti dengan id baru
+	req = httptest.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(requestid.Header, "bad id */")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	generated := rec.Header().Get(requestid.Header)
+	assert.True(t, requestid.Valid(generated))
+	assert.Equal(t, generated, rec.Body.String())
+
+	// Id dikirim pada body problem+json
+	req = httptest.NewRequest(http.MethodGet, "/not-found", nil)
+	req.Header.Set(requestid.Header, "client-id-2")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "client-id-2", rec.Header().Get(requestid.Header))
+	assert.Contains(t, rec.Body.String(), `"request_id":"client-id-2"`)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+// Kode error Postgres yang diterjemahkan menjadi error domain
+const (
+	pqStringTooLong        = "22001"
+	pqNotNullViolation     = "23502"
+	pqForeignKeyViolation  = "23503"
+	pqUniqueViolation      = "23505"
+	pqCheckViolation       = "23514"
+	pqSerializationFailure = "40001"
+)
+
+// constraintErrors memetakan nama constraint ke error domain yang lebih spesifik
+var constraintErrors = map[string]*apperror.Error{
+	"users_email_key":        entity.ErrEmailAlreadyExists,
+	"users_email_active_key": entity.ErrEmailAlreadyExists,
+}
+
+// translateError mengubah error dari driver Postgres menjadi error domain.
+// Pesan asli dari database tetap dibungkus sebagai cause untuk keperluan log,
+// tetapi tidak pernah dikirim ke client. Error lain hanya dibungkus dengan op.
+func translateError(err error, op string) error {
+	wrapped := fmt.Errorf("%s: %w", op, err)
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return wrapped
+	}
+
+	if known, ok := constraintErrors[pqErr.Constraint]; ok {
+		return known.WithCause(wrapped)
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return apperror.Wrap(wrapped, apperror.KindConflict, "already_exists", "resource already exists")
+	case pqForeignKeyViolation:
+		return apperror.Wrap(wrapped, apperror.KindConflict, "invalid_reference", "resource references or is referenced by another resource")
+	case pqSerializationFailure:
+		return apperror.Wrap(wrapped, apperror.KindConflict, "concurrent_update", "resource was modified concurrently, please retry")
+	case pqNotNullViolation, pqCheckViolation, pqStringTooLong:
+		return apperror.Wrap(wrapped, apperror.KindBadRequest, "invalid_value", "value violates a data constraint")
+	default:
+		return wrapped
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind apperror.Kind
+		code string
+	}{
+		{
+			name: "duplicate email",
+			err:  &pq.Error{Code: pqUniqueViolation, Constraint: "users_email_key"},
+			kind: apperror.KindConflict,
+			code: "email_already_exists",
+		},
+		{
+			name: "other unique violation",
+			err:  &pq.Error{Code: pqUniqueViolation, Constraint: "refresh_tokens_token_hash_key"},
+			kind: apperror.KindConflict,
+			code: "already_exists",
+		},
+		{
+			name: "foreign key violation",
+			err:  &pq.Error{Code: pqForeignKeyViolation},
+			kind: apperror.KindConflict,
+			code: "invalid_reference",
+		},
+		{
+			name: "value too long",
+			err:  &pq.Error{Code: pqStringTooLong},
+			kind: apperror.KindBadRequest,
+			code: "invalid_value",
+		},
+		{
+			name: "unknown database error",
+			err:  &pq.Error{Code: "XX000", Message: "secret internal detail"},
+			kind: apperror.KindInternal,
+			code: "internal_error",
+		},
+		{
+			name: "non database error",
+			err:  errors.New("connection refused"),
+			kind: apperror.KindInternal,
+			code: "internal_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := translateError(tt.err, "error creating user")
+			appErr := apperror.From(err)
+			assert.Equal(t, tt.kind, appErr.Kind)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.ErrorIs(t, err, tt.err)
+			assert.NotContains(t, appErr.Message, "secret")
+		})
+	}
+
+	assert.ErrorIs(t, translateError(&pq.Error{Code: pqUniqueViolation, Constraint: "users_email_key"}, "op"), entity.ErrEmailAlreadyExists)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+)
+
+// QueryObserver menerima durasi dan hasil setiap operasi repository,
+// misalnya untuk histogram metrics
+type QueryObserver interface {
+	ObserveQuery(operation string, duration time.Duration, err error)
+}
+
+// observe mencatat durasi operasi sejak start. Dipanggil dengan defer agar
+// error yang dikembalikan ikut tercatat.
+func observe(observer QueryObserver, operation string, start time.Time, err *error) {
+	observer.ObserveQuery(operation, time.Since(start), *err)
+}
+
+type instrumentedUserRepository struct {
+	next     repoInterface.UserRepository
+	observer QueryObserver
+}
+
+// NewInstrumentedUserRepository membungkus UserRepository sehingga setiap
+// operasi dicatat ke observer dengan nama "users.<Method>"
+func NewInstrumentedUserRepository(next repoInterface.UserRepository, observer QueryObserver) repoInterface.UserRepository {
+	return &instrumentedUserRepository{next: next, observer: observer}
+}
+
+func (r *instrumentedUserRepository) Create(ctx context.Context, user *entity.User) (err error) {
+	defer observe(r.observer, "users.Create", time.Now(), &err)
+	return r.next.Create(ctx, user)
+}
+
+func (r *instrumentedUserRepository) GetByID(ctx context.Context, id string) (_ *entity.User, err error) {
+	defer observe(r.observer, "users.GetByID", time.Now(), &err)
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *instrumentedUserRepository) GetByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	defer observe(r.observer, "users.GetByEmail", time.Now(), &err)
+	return r.next.GetByEmail(ctx, email)
+}
+
+func (r *instrumentedUserRepository) Update(ctx context.Context, user *entity.User) (err error) {
+	defer observe(r.observer, "users.Update", time.Now(), &err)
+	return r.next.Update(ctx, user)
+}
+
+func (r *instrumentedUserRepository) Patch(ctx context.Context, id string, patch entity.UserPatch) (_ *entity.User, err error) {
+	defer observe(r.observer, "users.Patch", time.Now(), &err)
+	return r.next.Patch(ctx, id, patch)
+}
+
+func (r *instrumentedUserRepository) Delete(ctx context.Context, id string) (err error) {
+	defer observe(r.observer, "users.Delete", time.Now(), &err)
+	return r.next.Delete(ctx, id)
+}
+
+func (r *instrumentedUserRepository) Restore(ctx context.Context, id string) (_ *entity.User, err error) {
+	defer observe(r.observer, "users.Restore", time.Now(), &err)
+	return r.next.Restore(ctx, id)
+}
+
+func (r *instrumentedUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (_ int64, err error) {
+	defer observe(r.observer, "users.PurgeDeleted", time.Now(), &err)
+	return r.next.PurgeDeleted(ctx, before)
+}
+
+func (r *instrumentedUserRepository) UpdatePasswordHash(ctx context.Context, id, currentHash, newHash string) (_ bool, err error) {
+	defer observe(r.observer, "users.UpdatePasswordHash", time.Now(), &err)
+	return r.next.UpdatePasswordHash(ctx, id, currentHash, newHash)
+}
+
+func (r *instrumentedUserRepository) List(ctx context.Context, query repoInterface.ListQuery) (_ []*entity.User, err error) {
+	defer observe(r.observer, "users.List", time.Now(), &err)
+	return r.next.List(ctx, query)
+}
+
+func (r *instrumentedUserRepository) ListPage(ctx context.Context, query repoInterface.PageQuery) (_ *repoInterface.Page[entity.User], err error) {
+	defer observe(r.observer, "users.ListPage", time.Now(), &err)
+	return r.next.ListPage(ctx, query)
+}
+
+func (r *instrumentedUserRepository) Count(ctx context.Context, query repoInterface.ListQuery) (_ int64, err error) {
+	defer observe(r.observer, "users.Count", time.Now(), &err)
+	return r.next.Count(ctx, query)
+}
+
+type instrumentedRefreshTokenRepository struct {
+	next     repoInterface.RefreshTokenRepository
+	observer QueryObserver
+}
+
+// NewInstrumentedRefreshTokenRepository membungkus RefreshTokenRepository
+// sehingga setiap operasi dicatat ke observer dengan nama "refresh_tokens.<Method>"
+func NewInstrumentedRefreshTokenRepository(next repoInterface.RefreshTokenRepository, observer QueryObserver) repoInterface.RefreshTokenRepository {
+	return &instrumentedRefreshTokenRepository{next: next, observer: observer}
+}
+
+func (r *instrumentedRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) (err error) {
+	defer observe(r.observer, "refresh_tokens.Create", time.Now(), &err)
+	return r.next.Create(ctx, token)
+}
+
+func (r *instrumentedRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (_ *entity.RefreshToken, err error) {
+	defer observe(r.observer, "refresh_tokens.GetByTokenHash", time.Now(), &err)
+	return r.next.GetByTokenHash(ctx, tokenHash)
+}
+
+func (r *instrumentedRefreshTokenRepository) Revoke(ctx context.Context, id, replacedBy string) (err error) {
+	defer observe(r.observer, "refresh_tokens.Revoke", time.Now(), &err)
+	return r.next.Revoke(ctx, id, replacedBy)
+}
+
+func (r *instrumentedRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) (err error) {
+	defer observe(r.observer, "refresh_tokens.RevokeFamily", time.Now(), &err)
+	return r.next.RevokeFamily(ctx, familyID)
+}
+
+type instrumentedRoleRepository struct {
+	next     repoInterface.RoleRepository
+	observer QueryObserver
+}
+
+// NewInstrumentedRoleRepository membungkus RoleRepository sehingga setiap
+// operasi dicatat ke observer dengan nama "roles.<Method>"
+func NewInstrumentedRoleRepository(next repoInterface.RoleRepository, observer QueryObserver) repoInterface.RoleRepository {
+	return &instrumentedRoleRepository{next: next, observer: observer}
+}
+
+func (r *instrumentedRoleRepository) ListPermissions(ctx context.Context, role string) (_ []string, err error) {
+	defer observe(r.observer, "roles.ListPermissions", time.Now(), &err)
+	return r.next.ListPermissions(ctx, role)
+}
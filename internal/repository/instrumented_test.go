@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubUserRepository hanya mengimplementasikan method yang dipakai test
+type stubUserRepository struct {
+	repoInterface.UserRepository
+}
+
+func (stubUserRepository) Create(ctx context.Context, user *entity.User) error {
+	return errors.New("connection refused")
+}
+
+func (stubUserRepository) GetByID(ctx context.Context, id string) (*entity.User, error) {
+	return &entity.User{ID: id}, nil
+}
+
+type observedQuery struct {
+	operation string
+	err       error
+}
+
+type recordingObserver struct {
+	queries []observedQuery
+}
+
+func (o *recordingObserver) ObserveQuery(operation string, duration time.Duration, err error) {
+	o.queries = append(o.queries, observedQuery{operation: operation, err: err})
+}
+
+func TestInstrumentedUserRepository(t *testing.T) {
+	observer := &recordingObserver{}
+	repo := NewInstrumentedUserRepository(stubUserRepository{}, observer)
+
+	user, err := repo.GetByID(context.Background(), "user-id")
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", user.ID)
+
+	err = repo.Create(context.Background(), &entity.User{})
+	assert.Error(t, err)
+
+	require.Len(t, observer.queries, 2)
+	assert.Equal(t, "users.GetByID", observer.queries[0].operation)
+	assert.NoError(t, observer.queries[0].err)
+	assert.Equal(t, "users.Create", observer.queries[1].operation)
+	assert.EqualError(t, observer.queries[1].err, "connection refused")
+}
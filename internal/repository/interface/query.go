@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Operator adalah operator perbandingan pada filter
+type Operator string
+
+const (
+	OpEq       Operator = "eq"
+	OpNe       Operator = "ne"
+	OpGt       Operator = "gt"
+	OpGte      Operator = "gte"
+	OpLt       Operator = "lt"
+	OpLte      Operator = "lte"
+	OpContains Operator = "contains"
+	OpPrefix   Operator = "prefix"
+)
+
+// FieldType menentukan cara nilai filter diparse sebelum dikirim ke database
+type FieldType int
+
+const (
+	FieldString FieldType = iota
+	FieldTime
+)
+
+// Filter adalah satu kondisi pada list query, misalnya email ^= "john"
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// SortField adalah satu field pengurutan
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListQuery adalah spesifikasi filter, pencarian, pengurutan dan pagination
+// untuk Repository.List. IncludeDeleted ikut menampilkan record yang sudah
+// di-soft delete.
+type ListQuery struct {
+	Filters        []Filter
+	Sort           []SortField
+	Search         string
+	Offset         int
+	Limit          int
+	IncludeDeleted bool
+}
+
+// Fingerprint adalah hash dari filter, pencarian, pengurutan dan
+// IncludeDeleted. Offset dan Limit tidak ikut dihitung. Urutan filter
+// dinormalisasi, sedangkan urutan sort tetap karena mengubah hasil.
+// Dipakai untuk mengikat cursor ke query yang menghasilkannya.
+func (q ListQuery) Fingerprint() string {
+	filters := make([]Filter, len(q.Filters))
+	copy(filters, q.Filters)
+	sort.Slice(filters, func(i, j int) bool {
+		a, b := filters[i], filters[j]
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if a.Operator != b.Operator {
+			return a.Operator < b.Operator
+		}
+		return a.Value < b.Value
+	})
+
+	// json.Marshal tidak pernah gagal untuk tipe-tipe ini
+	normalized, _ := json.Marshal(struct {
+		Filters        []Filter    `json:"f"`
+		Sort           []SortField `json:"s"`
+		Search         string      `json:"q"`
+		IncludeDeleted bool        `json:"d"`
+	}{filters, append([]SortField{}, q.Sort...), q.Search, q.IncludeDeleted})
+
+	sum := sha256.Sum256(normalized)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// FieldSpec adalah definisi field yang boleh dipakai pada list query
+type FieldSpec struct {
+	Column    string
+	Type      FieldType
+	Operators []Operator
+	Sortable  bool
+}
+
+// QuerySpec adalah whitelist field list query untuk satu entitas. Field yang
+// tidak terdaftar ditolak sehingga nama kolom tidak pernah berasal dari input.
+type QuerySpec struct {
+	Fields        map[string]FieldSpec
+	SearchColumns []string
+	DefaultSort   []SortField
+	// SoftDeleteColumn adalah kolom penanda soft delete. Baris yang kolom ini
+	// tidak NULL disembunyikan kecuali ListQuery.IncludeDeleted bernilai true.
+	SoftDeleteColumn string
+}
+
+// InvalidQueryError dikembalikan jika list query memakai field, operator
+// atau nilai yang tidak diizinkan
+type InvalidQueryError struct {
+	Field  string
+	Reason string
+}
+
+func (e *InvalidQueryError) Error() string {
+	return fmt.Sprintf("invalid query on field %q: %s", e.Field, e.Reason)
+}
+
+// Validate memeriksa list query terhadap whitelist
+func (s QuerySpec) Validate(q ListQuery) error {
+	for _, f := range q.Filters {
+		field, ok := s.Fields[f.Field]
+		if !ok {
+			return &InvalidQueryError{Field: f.Field, Reason: "filtering is not supported"}
+		}
+		if !field.allows(f.Operator) {
+			return &InvalidQueryError{Field: f.Field, Reason: fmt.Sprintf("operator %q is not supported", f.Operator)}
+		}
+	}
+	for _, sf := range q.Sort {
+		field, ok := s.Fields[sf.Field]
+		if !ok || !field.Sortable {
+			return &InvalidQueryError{Field: sf.Field, Reason: "sorting is not supported"}
+		}
+	}
+	return nil
+}
+
+func (f FieldSpec) allows(op Operator) bool {
+	for _, allowed := range f.Operators {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Cursor adalah posisi keyset pada pagination berbasis cursor. Urutan
+// keyset selalu (created_at DESC, id DESC).
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	// Backward bernilai true untuk mengambil halaman sebelum posisi ini
+	Backward bool
+}
+
+// PageQuery adalah list query untuk Repository.ListPage. Jika UseCursor
+// bernilai false, pagination memakai Offset/Limit seperti List; jika true,
+// Offset dan Sort diabaikan dan halaman dimulai dari Cursor (atau dari awal
+// jika Cursor nil).
+type PageQuery struct {
+	ListQuery
+	UseCursor    bool
+	Cursor       *Cursor
+	IncludeTotal bool
+}
+
+// Page adalah satu halaman hasil ListPage
+type Page[T any] struct {
+	Items      []*T
+	NextCursor *Cursor
+	PrevCursor *Cursor
+	// Total hanya diisi jika PageQuery.IncludeTotal bernilai true
+	Total *int64
+}
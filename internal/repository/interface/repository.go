@@ -2,18 +2,93 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
 )
 
-// Repository adalah interface dasar untuk semua repository
+// Repository adalah interface dasar untuk semua repository. GetByID, Update
+// dan Delete mengembalikan error not-found milik entitasnya (misalnya
+// entity.ErrUserNotFound) jika record tidak ada, bukan nil.
 type Repository[T any] interface {
 	Create(ctx context.Context, entity *T) error
 	GetByID(ctx context.Context, id string) (*T, error)
 	Update(ctx context.Context, entity *T) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, offset, limit int) ([]*T, error)
+	List(ctx context.Context, query ListQuery) ([]*T, error)
+	ListPage(ctx context.Context, query PageQuery) (*Page[T], error)
+	Count(ctx context.Context, query ListQuery) (int64, error)
+}
+
+// UserRepository adalah repository untuk entitas User
+type UserRepository interface {
+	Repository[entity.User]
+	// GetByEmail mengembalikan entity.ErrUserNotFound jika email tidak terdaftar
+	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	// Patch hanya mengubah kolom yang field-nya tidak nil dan mengembalikan
+	// user setelah diubah
+	Patch(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error)
+	// Restore membatalkan soft delete. Mengembalikan entity.ErrUserNotDeleted
+	// jika user masih aktif.
+	Restore(ctx context.Context, id string) (*entity.User, error)
+	// PurgeDeleted menghapus permanen user yang di-soft delete sebelum waktu
+	// before dan mengembalikan jumlah baris yang dihapus
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	// UpdatePasswordHash mengganti hash password tanpa optimistic locking dan
+	// tanpa menaikkan versi, untuk rehash saat login. Hash hanya diganti jika
+	// hash tersimpan masih currentHash agar perubahan password yang terjadi
+	// bersamaan tidak tertimpa; false berarti tidak ada baris yang diubah.
+	UpdatePasswordHash(ctx context.Context, id, currentHash, newHash string) (bool, error)
+}
+
+// RefreshTokenRepository adalah repository untuk refresh token
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entity.RefreshToken) error
+	// GetByTokenHash mengembalikan entity.ErrRefreshTokenNotFound jika token
+	// tidak dikenal
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// Revoke mencabut token yang masih aktif dan mencatat token penggantinya.
+	// Mengembalikan entity.ErrRefreshTokenReused jika token sudah dicabut sebelumnya.
+	Revoke(ctx context.Context, id, replacedBy string) error
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+// RoleRepository adalah repository untuk role dan permission
+type RoleRepository interface {
+	ListPermissions(ctx context.Context, role string) ([]string, error)
 }
 
-// Transactional adalah interface untuk mendukung transaksi database
+// DBTX adalah executor query yang dipenuhi oleh *sql.DB maupun *sql.Tx,
+// sehingga repository dapat berjalan di dalam maupun di luar transaksi
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Transactional adalah interface untuk mendukung transaksi database.
+// Transaksi dibawa melalui context yang diberikan ke fn, sehingga setiap
+// repository yang dipanggil dengan context tersebut ikut berjalan di dalam
+// transaksi. Pemanggilan bersarang menggunakan savepoint.
 type Transactional interface {
-	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+}
+
+// TxOption mengatur opsi transaksi. Opsi hanya berlaku untuk transaksi
+// terluar; pemanggilan bersarang mengikuti opsi transaksi induknya.
+type TxOption func(opts *sql.TxOptions)
+
+// WithIsolationLevel mengatur isolation level transaksi
+func WithIsolationLevel(level sql.IsolationLevel) TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.Isolation = level
+	}
+}
+
+// WithReadOnly menandai transaksi sebagai read-only
+func WithReadOnly() TxOption {
+	return func(opts *sql.TxOptions) {
+		opts.ReadOnly = true
+	}
 }
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+)
+
+var sqlOperators = map[repoInterface.Operator]string{
+	repoInterface.OpEq:       "=",
+	repoInterface.OpNe:       "<>",
+	repoInterface.OpGt:       ">",
+	repoInterface.OpGte:      ">=",
+	repoInterface.OpLt:       "<",
+	repoInterface.OpLte:      "<=",
+	repoInterface.OpContains: "ILIKE",
+	repoInterface.OpPrefix:   "ILIKE",
+}
+
+// timeLayouts adalah format yang diterima untuk filter bertipe waktu
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+
+// queryBuilder menyusun klausa WHERE dan ORDER BY dengan parameter bernomor
+// ($1, $2, ...). Nama kolom selalu diambil dari QuerySpec, tidak pernah dari input.
+type queryBuilder struct {
+	spec       repoInterface.QuerySpec
+	conditions []string
+	args       []any
+}
+
+func newQueryBuilder(spec repoInterface.QuerySpec) *queryBuilder {
+	return &queryBuilder{spec: spec}
+}
+
+// addArg menambahkan argumen dan mengembalikan placeholder-nya
+func (b *queryBuilder) addArg(value any) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// where menambahkan kondisi mentah. Gunakan addArg untuk setiap nilai.
+func (b *queryBuilder) where(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+// apply memvalidasi list query lalu menambahkan filter dan pencarian
+func (b *queryBuilder) apply(q repoInterface.ListQuery) error {
+	if err := b.spec.Validate(q); err != nil {
+		return err
+	}
+
+	for _, f := range q.Filters {
+		field := b.spec.Fields[f.Field]
+		value, err := parseFilterValue(field, f)
+		if err != nil {
+			return err
+		}
+		b.where(fmt.Sprintf("%s %s %s", field.Column, sqlOperators[f.Operator], b.addArg(value)))
+	}
+
+	if b.spec.SoftDeleteColumn != "" && !q.IncludeDeleted {
+		b.where(b.spec.SoftDeleteColumn + " IS NULL")
+	}
+
+	if search := strings.TrimSpace(q.Search); search != "" && len(b.spec.SearchColumns) > 0 {
+		placeholder := b.addArg("%" + escapeLike(search) + "%")
+		conditions := make([]string, len(b.spec.SearchColumns))
+		for i, column := range b.spec.SearchColumns {
+			conditions[i] = fmt.Sprintf("%s ILIKE %s", column, placeholder)
+		}
+		b.where("(" + strings.Join(conditions, " OR ") + ")")
+	}
+
+	return nil
+}
+
+// whereClause mengembalikan klausa WHERE, atau string kosong jika tidak ada kondisi
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// orderByClause menyusun ORDER BY dari sort pada query, atau sort default
+// dari spec. tieBreaker ditambahkan di akhir agar urutan selalu deterministik.
+func (b *queryBuilder) orderByClause(sort []repoInterface.SortField, tieBreaker string) string {
+	if len(sort) == 0 {
+		sort = b.spec.DefaultSort
+	}
+
+	parts := make([]string, 0, len(sort)+1)
+	for _, sf := range sort {
+		direction := "ASC"
+		if sf.Desc {
+			direction = "DESC"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", b.spec.Fields[sf.Field].Column, direction))
+	}
+	if tieBreaker != "" {
+		parts = append(parts, tieBreaker+" ASC")
+	}
+	return "ORDER BY " + strings.Join(parts, ", ")
+}
+
+func parseFilterValue(field repoInterface.FieldSpec, f repoInterface.Filter) (any, error) {
+	switch f.Operator {
+	case repoInterface.OpContains:
+		return "%" + escapeLike(f.Value) + "%", nil
+	case repoInterface.OpPrefix:
+		return escapeLike(f.Value) + "%", nil
+	}
+
+	if field.Type == repoInterface.FieldTime {
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, f.Value); err == nil {
+				return t, nil
+			}
+		}
+		return nil, &repoInterface.InvalidQueryError{Field: f.Field, Reason: "value must be an RFC 3339 timestamp or YYYY-MM-DD date"}
+	}
+	return f.Value, nil
+}
+
+// escapeLike meng-escape karakter wildcard LIKE agar input diperlakukan literal
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder_Filters(t *testing.T) {
+	qb := newQueryBuilder(userQuerySpec)
+	err := qb.apply(repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{
+			{Field: "email", Operator: repoInterface.OpPrefix, Value: "john_"},
+			{Field: "name", Operator: repoInterface.OpContains, Value: "50%"},
+			{Field: "created_at", Operator: repoInterface.OpGte, Value: "2024-01-01"},
+		},
+		Search: "doe",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"WHERE email ILIKE $1 AND name ILIKE $2 AND created_at >= $3 AND deleted_at IS NULL AND (email ILIKE $4 OR name ILIKE $4)",
+		qb.whereClause())
+	assert.Equal(t, []any{
+		`john\_%`,
+		`%50\%%`,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"%doe%",
+	}, qb.args)
+}
+
+func TestQueryBuilder_IncludeDeleted(t *testing.T) {
+	qb := newQueryBuilder(userQuerySpec)
+	require.NoError(t, qb.apply(repoInterface.ListQuery{}))
+	assert.Equal(t, "WHERE deleted_at IS NULL", qb.whereClause())
+
+	qb = newQueryBuilder(userQuerySpec)
+	require.NoError(t, qb.apply(repoInterface.ListQuery{IncludeDeleted: true}))
+	assert.Empty(t, qb.whereClause())
+}
+
+func TestQueryBuilder_OrderBy(t *testing.T) {
+	qb := newQueryBuilder(userQuerySpec)
+
+	assert.Equal(t, "ORDER BY created_at DESC, id ASC", qb.orderByClause(nil, "id"))
+	assert.Equal(t, "ORDER BY created_at DESC, name ASC, id ASC", qb.orderByClause([]repoInterface.SortField{
+		{Field: "created_at", Desc: true},
+		{Field: "name"},
+	}, "id"))
+}
+
+func TestQueryBuilder_RejectsUnknownFields(t *testing.T) {
+	tests := []repoInterface.ListQuery{
+		{Filters: []repoInterface.Filter{{Field: "password", Operator: repoInterface.OpEq, Value: "x"}}},
+		{Filters: []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpGt, Value: "x"}}},
+		{Filters: []repoInterface.Filter{{Field: "created_at", Operator: repoInterface.OpGte, Value: "yesterday"}}},
+		{Sort: []repoInterface.SortField{{Field: "password"}}},
+		{Sort: []repoInterface.SortField{{Field: "id; DROP TABLE users"}}},
+	}
+
+	for _, q := range tests {
+		err := newQueryBuilder(userQuerySpec).apply(q)
+		var invalidQuery *repoInterface.InvalidQueryError
+		assert.ErrorAs(t, err, &invalidQuery)
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+)
+
+// commentedConn menambahkan komentar berisi id request di awal setiap query,
+// sehingga query yang lambat pada pg_stat_activity atau log PostgreSQL dapat
+// dikaitkan dengan log aplikasi. Komentar diletakkan di awal karena
+// pg_stat_activity memotong query yang panjang.
+type commentedConn struct {
+	conn    repoInterface.DBTX
+	comment string
+}
+
+func (c commentedConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.conn.ExecContext(ctx, c.comment+query, args...)
+}
+
+func (c commentedConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.conn.QueryContext(ctx, c.comment+query, args...)
+}
+
+func (c commentedConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.conn.QueryRowContext(ctx, c.comment+query, args...)
+}
+
+// withQueryComment membungkus executor dengan commentedConn jika context
+// membawa id request. Id sudah divalidasi oleh requestid.Resolve sehingga
+// tidak dapat menutup komentar.
+func withQueryComment(ctx context.Context, db repoInterface.DBTX) repoInterface.DBTX {
+	id, ok := requestid.FromContext(ctx)
+	if !ok || !requestid.Valid(id) {
+		return db
+	}
+	return commentedConn{conn: db, comment: "/* request_id=" + id + " */ "}
+}
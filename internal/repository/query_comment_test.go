@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingConn mencatat query yang dieksekusi
+type recordingConn struct {
+	queries []string
+}
+
+func (r *recordingConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	r.queries = append(r.queries, query)
+	return nil, nil
+}
+
+func (r *recordingConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	r.queries = append(r.queries, query)
+	return nil, nil
+}
+
+func (r *recordingConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	r.queries = append(r.queries, query)
+	return nil
+}
+
+func TestWithQueryComment(t *testing.T) {
+	rec := &recordingConn{}
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	_, _ = withQueryComment(ctx, rec).ExecContext(ctx, "DELETE FROM users")
+	_, _ = withQueryComment(ctx, rec).QueryContext(ctx, "SELECT 1")
+	_ = withQueryComment(ctx, rec).QueryRowContext(ctx, "SELECT 2")
+
+	// Tanpa id request query tidak diubah
+	_, _ = withQueryComment(context.Background(), rec).ExecContext(context.Background(), "SELECT 3")
+
+	// Id yang tidak valid tidak pernah masuk ke query
+	bad := requestid.NewContext(context.Background(), "x */ DROP TABLE users; /*")
+	_, _ = withQueryComment(bad, rec).ExecContext(bad, "SELECT 4")
+
+	assert.Equal(t, []string{
+		"/* request_id=req-1 */ DELETE FROM users",
+		"/* request_id=req-1 */ SELECT 1",
+		"/* request_id=req-1 */ SELECT 2",
+		"SELECT 3",
+		"SELECT 4",
+	}, rec.queries)
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+)
+
+type refreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository membuat instance baru dari RefreshTokenRepository
+func NewRefreshTokenRepository(db *sql.DB) repoInterface.RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return translateError(err, "error creating refresh token")
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	token := &entity.RefreshToken{}
+	var revokedAt sql.NullTime
+	var replacedBy sql.NullString
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&revokedAt,
+		&replacedBy,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting refresh token: %w", err)
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	token.ReplacedBy = replacedBy.String
+	return token, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id, replacedBy string) error {
+	// Kondisi revoked_at IS NULL membuat rotasi atomik: dari dua request
+	// refresh yang bersamaan, hanya satu yang berhasil mencabut token
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1, replaced_by = NULLIF($2, '')
+		WHERE id = $3 AND revoked_at IS NULL
+	`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), replacedBy, id)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token: %w", err)
+	}
+	if affected == 0 {
+		return entity.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL
+	`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), familyID)
+	if err != nil {
+		return fmt.Errorf("error revoking refresh token family: %w", err)
+	}
+	return nil
+}
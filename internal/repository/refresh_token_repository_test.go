@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository_GetByTokenHash(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewUserRepository(db)
+	repo := NewRefreshTokenRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		Email:     "test@example.com",
+		Name:      "Test User",
+		Password:  "password123",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	token := &entity.RefreshToken{
+		ID:        "11111111-1111-1111-1111-111111111111",
+		UserID:    user.ID,
+		FamilyID:  "22222222-2222-2222-2222-222222222222",
+		TokenHash: "token-hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, token))
+
+	found, err := repo.GetByTokenHash(ctx, "token-hash")
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, found.ID)
+	assert.False(t, found.IsRevoked())
+
+	// Token yang tidak dikenal mengembalikan error not-found
+	notFound, err := repo.GetByTokenHash(ctx, "unknown-hash")
+	assert.ErrorIs(t, err, entity.ErrRefreshTokenNotFound)
+	assert.Nil(t, notFound)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+)
+
+type roleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository membuat instance baru dari RoleRepository
+func NewRoleRepository(db *sql.DB) repoInterface.RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context, role string) ([]string, error) {
+	query := `
+		SELECT permission
+		FROM role_permissions
+		WHERE role = $1
+		ORDER BY permission
+	`
+	var permissions []string
+	err := queryRows(ctx, conn(ctx, r.db), query, []any{role}, func(rows *sql.Rows) error {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return fmt.Errorf("error scanning role permission row: %w", err)
+		}
+		permissions = append(permissions, permission)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing role permissions: %w", err)
+	}
+	return permissions, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer dipakai untuk span setiap statement SQL
+var tracer = otel.Tracer("github.com/sekolahmu/boilerplate-go/internal/repository")
+
+const (
+	// rowsAffectedKey adalah atribut jumlah baris yang diubah oleh statement
+	rowsAffectedKey = attribute.Key("db.rows_affected")
+	// rowsReturnedKey adalah atribut jumlah baris yang dibaca dari query
+	rowsReturnedKey = attribute.Key("db.rows_returned")
+)
+
+// tracedConn membuat child span untuk setiap statement SQL berisi statement
+// dan, untuk ExecContext, jumlah baris yang diubah. Span QueryRowContext
+// berakhir sebelum Scan karena *sql.Row tidak memberi tahu kapan dibaca.
+// QueryContext tidak membuat span karena *sql.Rows tidak dapat dibungkus;
+// repository membaca rows lewat queryRows yang mengakhiri span setelah rows
+// ditutup.
+type tracedConn struct {
+	conn repoInterface.DBTX
+}
+
+func (c tracedConn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	result, err := c.conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		recordQueryError(span, err)
+		return result, err
+	}
+	if rows, rowsErr := result.RowsAffected(); rowsErr == nil {
+		span.SetAttributes(rowsAffectedKey.Int64(rows))
+	}
+	return result, nil
+}
+
+func (c tracedConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.conn.QueryContext(ctx, query, args...)
+}
+
+func (c tracedConn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	row := c.conn.QueryRowContext(ctx, query, args...)
+	if err := row.Err(); err != nil {
+		recordQueryError(span, err)
+	}
+	return row
+}
+
+// withTracing membungkus executor dengan tracedConn jika context membawa
+// span yang di-sampling, sehingga query dari background job tanpa trace
+// tidak membuat trace baru
+func withTracing(ctx context.Context, db repoInterface.DBTX) repoInterface.DBTX {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return db
+	}
+	return tracedConn{conn: db}
+}
+
+// queryRows menjalankan query lalu memanggil scan untuk setiap baris sampai
+// rows habis. Jika db dibungkus tracedConn, span query baru berakhir setelah
+// rows ditutup dan mencatat jumlah baris serta error dari rows.Err.
+func queryRows(ctx context.Context, db repoInterface.DBTX, query string, args []any, scan func(*sql.Rows) error) error {
+	traced, ok := db.(tracedConn)
+	if !ok {
+		_, err := readRows(ctx, db, query, args, scan)
+		return err
+	}
+
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	count, err := readRows(ctx, traced.conn, query, args, scan)
+	span.SetAttributes(rowsReturnedKey.Int(count))
+	if err != nil {
+		recordQueryError(span, err)
+	}
+	return err
+}
+
+func readRows(ctx context.Context, db repoInterface.DBTX, query string, args []any, scan func(*sql.Rows) error) (int, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := queryOperation(query)
+	return tracer.Start(ctx, "db."+strings.ToLower(operation),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+func recordQueryError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// queryOperation mengambil kata kunci pertama statement, misalnya SELECT
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
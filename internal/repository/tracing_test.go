@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeRowsDriver mengembalikan rows satu kolom berisi values, lalu err
+// setelah semua baris dibaca
+type fakeRowsDriver struct {
+	values []int64
+	err    error
+}
+
+func (d *fakeRowsDriver) Open(string) (driver.Conn, error) { return &fakeRowsConn{driver: d}, nil }
+
+type fakeRowsConn struct {
+	driver *fakeRowsDriver
+}
+
+func (c *fakeRowsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeRowsConn) Close() error                        { return nil }
+func (c *fakeRowsConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeRowsConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{values: c.driver.values, err: c.driver.err}, nil
+}
+
+type fakeRows struct {
+	values []int64
+	err    error
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		if r.err != nil {
+			return r.err
+		}
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+func openFakeRowsDB(t *testing.T, d *fakeRowsDriver) *sql.DB {
+	db := sql.OpenDB(driverConnector{d})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type driverConnector struct {
+	driver *fakeRowsDriver
+}
+
+func (c driverConnector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open("") }
+func (c driverConnector) Driver() driver.Driver                        { return c.driver }
+
+var (
+	spanRecorder     = tracetest.NewSpanRecorder()
+	setupTracingOnce sync.Once
+)
+
+// startTestSpan memulai span induk pada provider global yang dipasang sekali,
+// karena tracer paket terikat pada provider global pertama
+func startTestSpan() (context.Context, trace.Span) {
+	setupTracingOnce.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return otel.Tracer("test").Start(context.Background(), "handler")
+}
+
+// endedSpans mengembalikan span yang sudah berakhir pada trace milik parent
+func endedSpans(parent trace.Span) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range spanRecorder.Ended() {
+		if span.SpanContext().TraceID() == parent.SpanContext().TraceID() {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func TestWithTracing(t *testing.T) {
+	db := openFakeRowsDB(t, &fakeRowsDriver{})
+	ctx, parent := startTestSpan()
+
+	// Tanpa span induk query tidak membuat trace baru
+	ended := len(spanRecorder.Ended())
+	_, _ = withTracing(context.Background(), db).ExecContext(context.Background(), "DELETE FROM users")
+	assert.Len(t, spanRecorder.Ended(), ended)
+
+	_, _ = withTracing(ctx, db).ExecContext(ctx, "UPDATE users SET name = $1")
+	parent.End()
+
+	spans := endedSpans(parent)
+	require.Len(t, spans, 2)
+	assert.Equal(t, "db.update", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), semconv.DBStatement("UPDATE users SET name = $1"))
+	assert.Contains(t, spans[0].Attributes(), semconv.DBSystemPostgreSQL)
+}
+
+func TestQueryRows(t *testing.T) {
+	db := openFakeRowsDB(t, &fakeRowsDriver{values: []int64{1, 2, 3}})
+	ctx, parent := startTestSpan()
+	defer parent.End()
+
+	var ids []int64
+	err := queryRows(ctx, withTracing(ctx, db), "SELECT id FROM users", nil, func(rows *sql.Rows) error {
+		// Span masih terbuka selama rows dibaca
+		assert.Empty(t, endedSpans(parent))
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+
+	spans := endedSpans(parent)
+	require.Len(t, spans, 1)
+	assert.Equal(t, "db.select", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), rowsReturnedKey.Int(3))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestQueryRows_RowsError(t *testing.T) {
+	db := openFakeRowsDB(t, &fakeRowsDriver{values: []int64{1}, err: errors.New("connection reset")})
+	ctx, parent := startTestSpan()
+	defer parent.End()
+
+	err := queryRows(ctx, withTracing(ctx, db), "SELECT id FROM users", nil, func(rows *sql.Rows) error {
+		var id int64
+		return rows.Scan(&id)
+	})
+	assert.EqualError(t, err, "connection reset")
+
+	spans := endedSpans(parent)
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), rowsReturnedKey.Int(1))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "connection reset", spans[0].Status().Description)
+}
+
+func TestQueryRows_WithoutTracing(t *testing.T) {
+	db := openFakeRowsDB(t, &fakeRowsDriver{values: []int64{1, 2}})
+	_, parent := startTestSpan()
+	parent.End()
+	ended := len(spanRecorder.Ended())
+
+	count := 0
+	err := queryRows(context.Background(), withTracing(context.Background(), db), "SELECT id FROM users", nil, func(rows *sql.Rows) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, spanRecorder.Ended(), ended)
+}
+
+func TestQueryOperation(t *testing.T) {
+	assert.Equal(t, "UPDATE", queryOperation("\n\t\tupdate users SET name = $1"))
+	assert.Equal(t, "QUERY", queryOperation("  "))
+}
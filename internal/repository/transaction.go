@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+)
+
+type txKey struct{}
+
+// txState adalah transaksi aktif yang dibawa oleh context
+type txState struct {
+	tx         *sql.Tx
+	savepoints int
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor membuat unit of work yang menjalankan fn di dalam transaksi.
+// Repository yang dibuat dari db yang sama akan memakai transaksi tersebut
+// selama dipanggil dengan context yang diberikan ke fn.
+//
+// Transaksi tidak boleh dipakai bersamaan dari beberapa goroutine.
+func NewTransactor(db *sql.DB) repoInterface.Transactional {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoInterface.TxOption) error {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return withSavepoint(ctx, state, fn)
+	}
+
+	txOpts := &sql.TxOptions{}
+	for _, opt := range opts {
+		opt(txOpts)
+	}
+
+	tx, err := t.db.BeginTx(ctx, txOpts)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("error rolling back transaction: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// withSavepoint menjalankan fn di dalam savepoint pada transaksi yang sedang
+// aktif, sehingga kegagalan fn hanya membatalkan perubahan yang dibuat fn
+func withSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) error {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("error creating savepoint: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("error rolling back to savepoint: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("error releasing savepoint: %w", err)
+	}
+	return nil
+}
+
+// conn mengembalikan transaksi aktif pada context, atau db jika tidak ada.
+// Query ditandai dengan id request dari context dan dicatat sebagai span.
+func conn(ctx context.Context, db *sql.DB) repoInterface.DBTX {
+	var executor repoInterface.DBTX = db
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		executor = state.tx
+	}
+	return withTracing(ctx, withQueryComment(ctx, executor))
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUser(email string) *entity.User {
+	return &entity.User{
+		ID:        email,
+		Email:     email,
+		Name:      "Test User",
+		Password:  "password123",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func TestTransactor_CommitAndRollback(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+
+	// Perubahan di dalam transaksi yang berhasil akan di-commit
+	err := transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return repo.Create(ctx, newTestUser("commit@example.com"))
+	})
+	require.NoError(t, err)
+
+	user, err := repo.GetByID(ctx, "commit@example.com")
+	require.NoError(t, err)
+	assert.NotNil(t, user)
+
+	// Perubahan di dalam transaksi yang gagal akan di-rollback
+	errAbort := errors.New("abort")
+	err = transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, newTestUser("rollback@example.com")); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	user, err = repo.GetByID(ctx, "rollback@example.com")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+	assert.Nil(t, user)
+}
+
+func TestTransactor_NestedSavepoint(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	transactor := NewTransactor(db)
+	ctx := context.Background()
+
+	err := transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, newTestUser("outer@example.com")); err != nil {
+			return err
+		}
+
+		// Kegagalan transaksi bersarang hanya membatalkan savepoint-nya
+		innerErr := transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := repo.Create(ctx, newTestUser("inner@example.com")); err != nil {
+				return err
+			}
+			return errors.New("abort inner")
+		})
+		assert.Error(t, innerErr)
+		return nil
+	}, repoInterface.WithIsolationLevel(sql.LevelSerializable))
+	require.NoError(t, err)
+
+	outer, err := repo.GetByID(ctx, "outer@example.com")
+	require.NoError(t, err)
+	assert.NotNil(t, outer)
+
+	inner, err := repo.GetByID(ctx, "inner@example.com")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+	assert.Nil(t, inner)
+}
@@ -3,57 +3,103 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
 	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
 )
 
+// userQuerySpec adalah whitelist field yang dapat difilter dan diurutkan pada List
+var userQuerySpec = repoInterface.QuerySpec{
+	Fields: map[string]repoInterface.FieldSpec{
+		"id": {
+			Column:    "id",
+			Operators: []repoInterface.Operator{repoInterface.OpEq},
+		},
+		"email": {
+			Column:    "email",
+			Operators: []repoInterface.Operator{repoInterface.OpEq, repoInterface.OpNe, repoInterface.OpContains, repoInterface.OpPrefix},
+			Sortable:  true,
+		},
+		"name": {
+			Column:    "name",
+			Operators: []repoInterface.Operator{repoInterface.OpEq, repoInterface.OpNe, repoInterface.OpContains, repoInterface.OpPrefix},
+			Sortable:  true,
+		},
+		"role": {
+			Column:    "role",
+			Operators: []repoInterface.Operator{repoInterface.OpEq, repoInterface.OpNe},
+			Sortable:  true,
+		},
+		"created_at": {
+			Column:    "created_at",
+			Type:      repoInterface.FieldTime,
+			Operators: []repoInterface.Operator{repoInterface.OpGt, repoInterface.OpGte, repoInterface.OpLt, repoInterface.OpLte},
+			Sortable:  true,
+		},
+		"updated_at": {
+			Column:    "updated_at",
+			Type:      repoInterface.FieldTime,
+			Operators: []repoInterface.Operator{repoInterface.OpGt, repoInterface.OpGte, repoInterface.OpLt, repoInterface.OpLte},
+			Sortable:  true,
+		},
+		"deleted_at": {
+			Column:    "deleted_at",
+			Type:      repoInterface.FieldTime,
+			Operators: []repoInterface.Operator{repoInterface.OpGt, repoInterface.OpGte, repoInterface.OpLt, repoInterface.OpLte},
+		},
+	},
+	SearchColumns:    []string{"email", "name"},
+	DefaultSort:      []repoInterface.SortField{{Field: "created_at", Desc: true}},
+	SoftDeleteColumn: "deleted_at",
+}
+
+// userColumns adalah kolom yang dibaca untuk setiap user, sesuai urutan scanUser
+const userColumns = "id, email, name, password, role, created_at, updated_at, version, deleted_at"
+
 type userRepository struct {
 	db *sql.DB
 }
 
 // NewUserRepository membuat instance baru dari UserRepository
-func NewUserRepository(db *sql.DB) repoInterface.Repository[entity.User] {
+func NewUserRepository(db *sql.DB) repoInterface.UserRepository {
 	return &userRepository{db: db}
 }
 
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	query := `
-		INSERT INTO users (id, email, name, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (id, email, name, password, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING version
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
 		user.ID,
 		user.Email,
 		user.Name,
 		user.Password,
+		user.Role,
 		user.CreatedAt,
 		user.UpdatedAt,
-	)
+	).Scan(&user.Version)
 	if err != nil {
-		return fmt.Errorf("error creating user: %w", err)
+		return translateError(err, "error creating user")
 	}
 	return nil
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*entity.User, error) {
 	query := `
-		SELECT id, email, name, password, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-	if err == sql.ErrNoRows {
-		return nil, nil
+	err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, query, id), user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrUserNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error getting user by id: %w", err)
@@ -61,89 +107,342 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*entity.User,
 	return user, nil
 }
 
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL
+	`
+	user := &entity.User{}
+	err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, query, email), user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting user by email: %w", err)
+	}
+	return user, nil
+}
+
+// Update mengubah user dalam satu round-trip. Password dan role yang kosong
+// tidak diubah; nilai yang tersimpan beserta created_at dan versi baru
+// dikembalikan ke user. Jika user.Version tidak nol, update hanya dilakukan
+// bila versi di database masih sama (optimistic locking).
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users
-		SET email = $1, name = $2, password = $3, updated_at = $4
-		WHERE id = $5
+		SET email = $1,
+			name = $2,
+			password = COALESCE(NULLIF($3, ''), password),
+			role = COALESCE(NULLIF($4, ''), role),
+			updated_at = $5,
+			version = version + 1
+		WHERE id = $6 AND deleted_at IS NULL AND ($7 = 0 OR version = $7)
+		RETURNING password, role, created_at, version
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
 		user.Email,
 		user.Name,
 		user.Password,
+		user.Role,
 		user.UpdatedAt,
 		user.ID,
-	)
+		user.Version,
+	).Scan(&user.Password, &user.Role, &user.CreatedAt, &user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.missingUserError(ctx, user.ID)
+	}
 	if err != nil {
-		return fmt.Errorf("error updating user: %w", err)
+		return translateError(err, "error updating user")
 	}
 	return nil
 }
 
+func (r *userRepository) Patch(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	var sets []string
+	var args []any
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if patch.Email != nil {
+		set("email", *patch.Email)
+	}
+	if patch.Name != nil {
+		set("name", *patch.Name)
+	}
+	if patch.Password != nil {
+		set("password", *patch.Password)
+	}
+	if patch.Role != nil {
+		set("role", *patch.Role)
+	}
+	set("updated_at", patch.UpdatedAt)
+	sets = append(sets, "version = version + 1")
+	args = append(args, id, patch.Version)
+
+	query := fmt.Sprintf(`
+		UPDATE users
+		SET %s
+		WHERE id = $%d AND deleted_at IS NULL AND ($%d = 0 OR version = $%d)
+		RETURNING %s
+	`, strings.Join(sets, ", "), len(args)-1, len(args), len(args), userColumns)
+
+	user := &entity.User{}
+	err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, query, args...), user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.missingUserError(ctx, id)
+	}
+	if err != nil {
+		return nil, translateError(err, "error patching user")
+	}
+	return user, nil
+}
+
+// missingUserError dipanggil ketika update bersyarat tidak mengenai baris
+// apa pun, untuk membedakan user yang tidak ada dengan versi yang sudah berubah
+func (r *userRepository) missingUserError(ctx context.Context, id string) error {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)`
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("error checking user existence: %w", err)
+	}
+	if exists {
+		return entity.ErrUserVersionConflict
+	}
+	return entity.ErrUserNotFound
+}
+
+// Delete melakukan soft delete. Data user tetap tersimpan sampai dihapus
+// permanen oleh PurgeDeleted.
 func (r *userRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	query := `
+		UPDATE users
+		SET deleted_at = $1, version = version + 1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return translateError(err, "error deleting user")
+	}
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("error deleting user: %w", err)
 	}
+	if affected == 0 {
+		return entity.ErrUserNotFound
+	}
 	return nil
 }
 
-func (r *userRepository) List(ctx context.Context, offset, limit int) ([]*entity.User, error) {
+func (r *userRepository) Restore(ctx context.Context, id string) (*entity.User, error) {
 	query := `
-		SELECT id, email, name, password, created_at, updated_at
-		FROM users
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+		UPDATE users
+		SET deleted_at = NULL, updated_at = $1, version = version + 1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+		RETURNING ` + userColumns
+
+	user := &entity.User{}
+	err := scanUser(conn(ctx, r.db).QueryRowContext(ctx, query, time.Now(), id), user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.missingDeletedUserError(ctx, id)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error listing users: %w", err)
+		return nil, translateError(err, "error restoring user")
 	}
-	defer rows.Close()
+	return user, nil
+}
 
-	var users []*entity.User
-	for rows.Next() {
-		user := &entity.User{}
-		err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.Name,
-			&user.Password,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+// missingDeletedUserError membedakan user yang tidak ada dengan user yang
+// masih aktif ketika Restore tidak mengenai baris apa pun
+func (r *userRepository) missingDeletedUserError(ctx context.Context, id string) error {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("error checking user existence: %w", err)
+	}
+	if exists {
+		return entity.ErrUserNotDeleted
+	}
+	return entity.ErrUserNotFound
+}
+
+func (r *userRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, translateError(err, "error purging deleted users")
+	}
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error purging deleted users: %w", err)
+	}
+	return purged, nil
+}
+
+func (r *userRepository) UpdatePasswordHash(ctx context.Context, id, currentHash, newHash string) (bool, error) {
+	query := `UPDATE users SET password = $1 WHERE id = $2 AND password = $3 AND deleted_at IS NULL`
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, newHash, id, currentHash)
+	if err != nil {
+		return false, translateError(err, "error updating password hash")
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error updating password hash: %w", err)
+	}
+	return updated > 0, nil
+}
+
+func (r *userRepository) List(ctx context.Context, q repoInterface.ListQuery) ([]*entity.User, error) {
+	qb := newQueryBuilder(userQuerySpec)
+	if err := qb.apply(q); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM users
+		%s
+		%s
+		LIMIT %s OFFSET %s
+	`, userColumns, qb.whereClause(), qb.orderByClause(q.Sort, "id"), qb.addArg(q.Limit), qb.addArg(q.Offset))
+
+	return r.queryUsers(ctx, query, qb.args)
+}
+
+// ListPage mengembalikan satu halaman user. Pada mode cursor, halaman diambil
+// dengan keyset (created_at, id) sehingga performanya tidak bergantung pada
+// posisi halaman dan tidak bergeser ketika ada insert baru.
+func (r *userRepository) ListPage(ctx context.Context, q repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	page := &repoInterface.Page[entity.User]{}
+
+	if q.IncludeTotal {
+		total, err := r.Count(ctx, q.ListQuery)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning user row: %w", err)
+			return nil, err
 		}
-		users = append(users, user)
+		page.Total = &total
+	}
+
+	if !q.UseCursor {
+		users, err := r.List(ctx, q.ListQuery)
+		if err != nil {
+			return nil, err
+		}
+		page.Items = users
+		return page, nil
+	}
+
+	qb := newQueryBuilder(userQuerySpec)
+	if err := qb.apply(repoInterface.ListQuery{Filters: q.Filters, Search: q.Search, IncludeDeleted: q.IncludeDeleted}); err != nil {
+		return nil, err
+	}
+
+	backward := q.Cursor != nil && q.Cursor.Backward
+	direction, comparison := "DESC", "<"
+	if backward {
+		direction, comparison = "ASC", ">"
+	}
+	if q.Cursor != nil {
+		// created_at bertipe TIMESTAMP tanpa zona waktu, sehingga cursor
+		// dibandingkan berdasarkan nilai wall clock yang sama saat dibaca
+		qb.where(fmt.Sprintf("(created_at, id) %s (%s::timestamp, %s)",
+			comparison, qb.addArg(q.Cursor.CreatedAt), qb.addArg(q.Cursor.ID)))
 	}
-	return users, nil
-}
 
-func (r *userRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+	// Ambil satu baris lebih untuk mengetahui apakah masih ada halaman berikutnya
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM users
+		%s
+		ORDER BY created_at %s, id %s
+		LIMIT %s
+	`, userColumns, qb.whereClause(), direction, direction, qb.addArg(q.Limit+1))
+
+	users, err := r.queryUsers(ctx, query, qb.args)
 	if err != nil {
-		return fmt.Errorf("error beginning transaction: %w", err)
+		return nil, err
 	}
 
-	defer func() {
-		if p := recover(); p != nil {
-			_ = tx.Rollback()
-			panic(p)
+	hasMore := len(users) > q.Limit
+	if hasMore {
+		users = users[:q.Limit]
+	}
+	if backward {
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
 		}
-	}()
+	}
 
-	if err := fn(ctx); err != nil {
-		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("error rolling back transaction: %v (original error: %w)", rbErr, err)
+	page.Items = users
+	if len(users) > 0 {
+		// Halaman maju punya halaman berikutnya jika masih ada baris, dan
+		// punya halaman sebelumnya jika dimulai dari cursor; berlaku sebaliknya
+		// untuk halaman mundur
+		first, last := users[0], users[len(users)-1]
+		if hasMore || backward {
+			page.NextCursor = &repoInterface.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+		}
+		if (backward && hasMore) || (!backward && q.Cursor != nil) {
+			page.PrevCursor = &repoInterface.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Backward: true}
 		}
-		return err
 	}
+	return page, nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("error committing transaction: %w", err)
+func (r *userRepository) Count(ctx context.Context, q repoInterface.ListQuery) (int64, error) {
+	qb := newQueryBuilder(userQuerySpec)
+	if err := qb.apply(repoInterface.ListQuery{Filters: q.Filters, Search: q.Search, IncludeDeleted: q.IncludeDeleted}); err != nil {
+		return 0, err
 	}
 
-	return nil
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM users %s`, qb.whereClause())
+
+	var total int64
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, qb.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("error counting users: %w", err)
+	}
+	return total, nil
+}
+
+// rowScanner adalah bagian yang sama dari *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser membaca satu baris dengan urutan kolom userColumns
+func scanUser(row rowScanner, user *entity.User) error {
+	return row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Version,
+		&user.DeletedAt,
+	)
+}
+
+// queryUsers menjalankan query yang memilih userColumns lalu membaca semua baris
+func (r *userRepository) queryUsers(ctx context.Context, query string, args []any) ([]*entity.User, error) {
+	users := []*entity.User{}
+	err := queryRows(ctx, conn(ctx, r.db), query, args, func(rows *sql.Rows) error {
+		user := &entity.User{}
+		if err := scanUser(rows, user); err != nil {
+			return fmt.Errorf("error scanning user row: %w", err)
+		}
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoInterface.TxOption) error {
+	return NewTransactor(r.db).WithTransaction(ctx, fn, opts...)
 }
@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
+	_ "github.com/lib/pq"
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -17,7 +20,7 @@ func setupTestDB(t *testing.T) *sql.DB {
 	require.NoError(t, err)
 
 	// Bersihkan tabel sebelum test
-	_, err = db.Exec("TRUNCATE TABLE users")
+	_, err = db.Exec("TRUNCATE TABLE users CASCADE")
 	require.NoError(t, err)
 
 	return db
@@ -34,6 +37,7 @@ func TestUserRepository_Create(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Password:  "password123",
+		Role:      entity.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -63,6 +67,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Password:  "password123",
+		Role:      entity.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -79,7 +84,7 @@ func TestUserRepository_GetByID(t *testing.T) {
 
 	// Test GetByID dengan ID yang tidak ada
 	notFoundUser, err := repo.GetByID(ctx, "non-existent-id")
-	require.NoError(t, err)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
 	assert.Nil(t, notFoundUser)
 }
 
@@ -95,6 +100,7 @@ func TestUserRepository_Update(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Password:  "password123",
+		Role:      entity.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -113,6 +119,89 @@ func TestUserRepository_Update(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Updated Name", updatedUser.Name)
 	assert.Equal(t, "updated@example.com", updatedUser.Email)
+
+	// Password dan role kosong tidak mengubah nilai yang tersimpan
+	partial := &entity.User{ID: user.ID, Email: user.Email, Name: "Partial Name", UpdatedAt: time.Now()}
+	err = repo.Update(ctx, partial)
+	require.NoError(t, err)
+	assert.Equal(t, "password123", partial.Password)
+	assert.Equal(t, entity.RoleUser, partial.Role)
+	assert.False(t, partial.CreatedAt.IsZero())
+
+	// Update user yang tidak ada
+	err = repo.Update(ctx, &entity.User{ID: "non-existent-id", Email: "x@example.com", Name: "X", UpdatedAt: time.Now()})
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func TestUserRepository_Patch(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		ID:        "patch-id",
+		Email:     "test@example.com",
+		Name:      "Test User",
+		Password:  "password123",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	// Hanya name yang berubah
+	name := "Patched Name"
+	patched, err := repo.Patch(ctx, user.ID, entity.UserPatch{Name: &name, UpdatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, "Patched Name", patched.Name)
+	assert.Equal(t, "test@example.com", patched.Email)
+	assert.Equal(t, "password123", patched.Password)
+	assert.Equal(t, entity.RoleUser, patched.Role)
+
+	_, err = repo.Patch(ctx, "non-existent-id", entity.UserPatch{Name: &name, UpdatedAt: time.Now()})
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func TestUserRepository_OptimisticLocking(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		ID:        "locking-id",
+		Email:     "test@example.com",
+		Name:      "Test User",
+		Password:  "password123",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+	assert.Equal(t, 1, user.Version)
+
+	// Dua admin membaca versi yang sama
+	first := *user
+	second := *user
+
+	first.Name = "First Admin"
+	require.NoError(t, repo.Update(ctx, &first))
+	assert.Equal(t, 2, first.Version)
+
+	second.Name = "Second Admin"
+	err := repo.Update(ctx, &second)
+	assert.ErrorIs(t, err, entity.ErrUserVersionConflict)
+
+	name := "Patched"
+	_, err = repo.Patch(ctx, user.ID, entity.UserPatch{Name: &name, UpdatedAt: time.Now(), Version: 1})
+	assert.ErrorIs(t, err, entity.ErrUserVersionConflict)
+
+	patched, err := repo.Patch(ctx, user.ID, entity.UserPatch{Name: &name, UpdatedAt: time.Now(), Version: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, patched.Version)
 }
 
 func TestUserRepository_Delete(t *testing.T) {
@@ -127,6 +216,7 @@ func TestUserRepository_Delete(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Password:  "password123",
+		Role:      entity.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -139,8 +229,104 @@ func TestUserRepository_Delete(t *testing.T) {
 
 	// Verifikasi user sudah dihapus
 	deletedUser, err := repo.GetByID(ctx, user.ID)
-	require.NoError(t, err)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
 	assert.Nil(t, deletedUser)
+
+	// Menghapus user yang sudah tidak ada
+	err = repo.Delete(ctx, user.ID)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func TestUserRepository_SoftDelete(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		Email:     "test@example.com",
+		Name:      "Test User",
+		Password:  "password123",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	// Restore user yang masih aktif
+	_, err := repo.Restore(ctx, user.ID)
+	assert.ErrorIs(t, err, entity.ErrUserNotDeleted)
+
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	// User yang dihapus tidak muncul kecuali IncludeDeleted
+	users, err := repo.List(ctx, repoInterface.ListQuery{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	users, err = repo.List(ctx, repoInterface.ListQuery{Limit: 10, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.NotNil(t, users[0].DeletedAt)
+
+	// Email user yang dihapus boleh dipakai ulang selama belum di-restore
+	_, err = repo.GetByEmail(ctx, user.Email)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+	restored, err := repo.Restore(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, user.Version+2, restored.Version)
+
+	_, err = repo.Restore(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+	// Purge hanya menghapus user yang dihapus sebelum batas waktu
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	purged, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	purged, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = repo.Restore(ctx, user.ID)
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+}
+
+func TestUserRepository_UpdatePasswordHash(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := &entity.User{
+		Email:     "rehash@example.com",
+		Name:      "Rehash User",
+		Password:  "old-hash",
+		Role:      entity.RoleUser,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	updated, err := repo.UpdatePasswordHash(ctx, user.ID, "old-hash", "new-hash")
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// Versi tidak berubah sehingga update dengan versi lama tetap berhasil
+	saved, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", saved.Password)
+	assert.Equal(t, user.Version, saved.Version)
+
+	// Hash yang sudah berubah tidak ditimpa
+	updated, err = repo.UpdatePasswordHash(ctx, user.ID, "old-hash", "other-hash")
+	require.NoError(t, err)
+	assert.False(t, updated)
 }
 
 func TestUserRepository_List(t *testing.T) {
@@ -156,6 +342,7 @@ func TestUserRepository_List(t *testing.T) {
 			Email:     "user1@example.com",
 			Name:      "User 1",
 			Password:  "password123",
+			Role:      entity.RoleUser,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
@@ -163,6 +350,7 @@ func TestUserRepository_List(t *testing.T) {
 			Email:     "user2@example.com",
 			Name:      "User 2",
 			Password:  "password123",
+			Role:      entity.RoleUser,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
@@ -170,6 +358,7 @@ func TestUserRepository_List(t *testing.T) {
 			Email:     "user3@example.com",
 			Name:      "User 3",
 			Password:  "password123",
+			Role:      entity.RoleUser,
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
@@ -181,12 +370,111 @@ func TestUserRepository_List(t *testing.T) {
 	}
 
 	// Test List dengan pagination
-	list, err := repo.List(ctx, 0, 2)
+	list, err := repo.List(ctx, repoInterface.ListQuery{Offset: 0, Limit: 2})
 	require.NoError(t, err)
 	assert.Len(t, list, 2)
 
 	// Test List dengan offset
-	list, err = repo.List(ctx, 2, 2)
+	list, err = repo.List(ctx, repoInterface.ListQuery{Offset: 2, Limit: 2})
 	require.NoError(t, err)
 	assert.Len(t, list, 1)
+
+	// Test List dengan filter dan sort
+	list, err = repo.List(ctx, repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpNe, Value: "user2@example.com"}},
+		Sort:    []repoInterface.SortField{{Field: "name", Desc: true}},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "User 3", list[0].Name)
+	assert.Equal(t, "User 1", list[1].Name)
+
+	// Test List dengan pencarian
+	list, err = repo.List(ctx, repoInterface.ListQuery{Search: "user3", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "user3@example.com", list[0].Email)
+
+	// Field di luar whitelist ditolak
+	_, err = repo.List(ctx, repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{{Field: "password", Operator: repoInterface.OpEq, Value: "x"}},
+		Limit:   10,
+	})
+	var invalidQuery *repoInterface.InvalidQueryError
+	assert.ErrorAs(t, err, &invalidQuery)
+}
+
+func TestUserRepository_ListPage(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	// Buat 5 user dengan created_at berurutan
+	base := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	for i := 0; i < 5; i++ {
+		user := &entity.User{
+			ID:        fmt.Sprintf("user-%d", i),
+			Email:     fmt.Sprintf("user%d@example.com", i),
+			Name:      fmt.Sprintf("User %d", i),
+			Password:  "password123",
+			Role:      entity.RoleUser,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+			UpdatedAt: base,
+		}
+		require.NoError(t, repo.Create(ctx, user))
+	}
+
+	// Halaman pertama berisi user terbaru
+	page, err := repo.ListPage(ctx, repoInterface.PageQuery{
+		ListQuery:    repoInterface.ListQuery{Limit: 2},
+		UseCursor:    true,
+		IncludeTotal: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "user-4", page.Items[0].ID)
+	assert.Equal(t, "user-3", page.Items[1].ID)
+	assert.Nil(t, page.PrevCursor)
+	require.NotNil(t, page.NextCursor)
+	require.NotNil(t, page.Total)
+	assert.Equal(t, int64(5), *page.Total)
+
+	// Halaman kedua dimulai setelah cursor
+	page, err = repo.ListPage(ctx, repoInterface.PageQuery{
+		ListQuery: repoInterface.ListQuery{Limit: 2},
+		UseCursor: true,
+		Cursor:    page.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, "user-2", page.Items[0].ID)
+	assert.Equal(t, "user-1", page.Items[1].ID)
+	require.NotNil(t, page.PrevCursor)
+	require.NotNil(t, page.NextCursor)
+
+	// Kembali ke halaman sebelumnya
+	prev, err := repo.ListPage(ctx, repoInterface.PageQuery{
+		ListQuery: repoInterface.ListQuery{Limit: 2},
+		UseCursor: true,
+		Cursor:    page.PrevCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, prev.Items, 2)
+	assert.Equal(t, "user-4", prev.Items[0].ID)
+	assert.Equal(t, "user-3", prev.Items[1].ID)
+	assert.Nil(t, prev.PrevCursor)
+
+	// Halaman terakhir tidak memiliki next cursor
+	page, err = repo.ListPage(ctx, repoInterface.PageQuery{
+		ListQuery: repoInterface.ListQuery{Limit: 2},
+		UseCursor: true,
+		Cursor:    page.NextCursor,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "user-0", page.Items[0].ID)
+	assert.Nil(t, page.NextCursor)
 }
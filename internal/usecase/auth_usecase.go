@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type authUseCase struct {
+	userUseCase      usecase_interface.UserUseCase
+	refreshTokenRepo repoInterface.RefreshTokenRepository
+	roleRepo         repoInterface.RoleRepository
+	transactor       repoInterface.Transactional
+	tokenManager     *auth.TokenManager
+}
+
+// NewAuthUseCase membuat instance baru dari AuthUseCase
+func NewAuthUseCase(
+	userUseCase usecase_interface.UserUseCase,
+	refreshTokenRepo repoInterface.RefreshTokenRepository,
+	roleRepo repoInterface.RoleRepository,
+	transactor repoInterface.Transactional,
+	tokenManager *auth.TokenManager,
+) usecase_interface.AuthUseCase {
+	return &authUseCase{
+		userUseCase:      userUseCase,
+		refreshTokenRepo: refreshTokenRepo,
+		roleRepo:         roleRepo,
+		transactor:       transactor,
+		tokenManager:     tokenManager,
+	}
+}
+
+func (uc *authUseCase) Login(ctx context.Context, email, password string) (*entity.TokenPair, error) {
+	user, err := uc.userUseCase.VerifyCredentials(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setiap login memulai family refresh token yang baru
+	return uc.issueTokenPair(ctx, user, uuid.New().String(), "")
+}
+
+// Refresh merotasi refresh token. Token lama dicabut dan diganti token baru
+// dalam family yang sama. Jika token yang sudah dirotasi dipakai kembali,
+// seluruh family dicabut karena token tersebut kemungkinan besar bocor.
+// Token yang tidak dikenal, sudah kedaluwarsa atau dicabut karena logout
+// hanya ditolak.
+func (uc *authUseCase) Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error) {
+	current, err := uc.refreshTokenRepo.GetByTokenHash(ctx, auth.HashRefreshToken(refreshToken))
+	if errors.Is(err, entity.ErrRefreshTokenNotFound) {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if current.IsRotated() {
+		return nil, uc.revokeFamilyOnReuse(ctx, current.FamilyID)
+	}
+	if current.IsRevoked() || current.IsExpired(time.Now()) {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+
+	user, err := uc.userUseCase.GetUserByID(ctx, current.UserID)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return nil, entity.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Pencabutan token lama dan penerbitan token baru harus atomik agar user
+	// tidak kehilangan sesi jika penyimpanan token baru gagal
+	var tokens *entity.TokenPair
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		newID := uuid.New().String()
+		if err := uc.refreshTokenRepo.Revoke(ctx, current.ID, newID); err != nil {
+			return err
+		}
+		tokens, err = uc.issueTokenPair(ctx, user, current.FamilyID, newID)
+		return err
+	})
+	if errors.Is(err, entity.ErrRefreshTokenReused) {
+		return nil, uc.revokeFamilyOnReuse(ctx, current.FamilyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (uc *authUseCase) Logout(ctx context.Context, refreshToken string) error {
+	current, err := uc.refreshTokenRepo.GetByTokenHash(ctx, auth.HashRefreshToken(refreshToken))
+	// Logout bersifat idempoten, token yang tidak dikenal diabaikan
+	if errors.Is(err, entity.ErrRefreshTokenNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return uc.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID)
+}
+
+func (uc *authUseCase) issueTokenPair(ctx context.Context, user *entity.User, familyID, tokenID string) (*entity.TokenPair, error) {
+	permissions, err := uc.roleRepo.ListPermissions(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, accessExpiresAt, err := uc.tokenManager.IssueAccessToken(user, permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRefreshToken, refreshTokenHash, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenID == "" {
+		tokenID = uuid.New().String()
+	}
+	now := time.Now()
+	refreshToken := &entity.RefreshToken{
+		ID:        tokenID,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: now.Add(uc.tokenManager.RefreshTokenTTL()),
+		CreatedAt: now,
+	}
+	if err := uc.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+
+	return &entity.TokenPair{
+		AccessToken:      accessToken,
+		TokenType:        auth.TokenType,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     rawRefreshToken,
+		RefreshExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
+
+func (uc *authUseCase) revokeFamilyOnReuse(ctx context.Context, familyID string) error {
+	// Reuse menandakan refresh token kemungkinan dicuri, sehingga dicatat
+	// sebagai warning untuk audit
+	logger.FromContext(ctx).Warn("refresh token reuse detected, revoking token family",
+		zap.String("family_id", familyID))
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, familyID); err != nil {
+		return err
+	}
+	return entity.ErrRefreshTokenReused
+}
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserUseCase adalah mock untuk UserUseCase
+type MockUserUseCase struct {
+	mock.Mock
+}
+
+func (m *MockUserUseCase) CreateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) UpdateUser(ctx context.Context, user *entity.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) PatchUser(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) DeleteUser(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserUseCase) RestoreUser(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsers(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterface.Page[entity.User]), args.Error(1)
+}
+
+func (m *MockUserUseCase) VerifyCredentials(ctx context.Context, email, password string) (*entity.User, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserUseCase) IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error) {
+	args := m.Called(ctx, email, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockRefreshTokenRepository adalah mock untuk RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) Revoke(ctx context.Context, id, replacedBy string) error {
+	args := m.Called(ctx, id, replacedBy)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	args := m.Called(ctx, familyID)
+	return args.Error(0)
+}
+
+// MockRoleRepository adalah mock untuk RoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) ListPermissions(ctx context.Context, role string) ([]string, error) {
+	args := m.Called(ctx, role)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func newTestRoleRepository() *MockRoleRepository {
+	mockRoleRepo := new(MockRoleRepository)
+	mockRoleRepo.On("ListPermissions", mock.Anything, entity.RoleUser).
+		Return([]string{entity.PermissionUsersRead, entity.PermissionUsersUpdate}, nil)
+	return mockRoleRepo
+}
+
+func newTestTokenManager(t *testing.T) *auth.TokenManager {
+	manager, err := auth.NewTokenManager(config.AuthConfig{
+		JWTAlgorithm:    auth.AlgorithmHS256,
+		JWTSecret:       "test-secret-that-is-at-least-32-bytes",
+		Issuer:          "boilerplate-go-test",
+		AccessTokenTTL:  60,
+		RefreshTokenTTL: 3600,
+	})
+	require.NoError(t, err)
+	return manager
+}
+
+func TestAuthUseCase_Login(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	tokenManager := newTestTokenManager(t)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), tokenManager)
+	ctx := context.Background()
+
+	user := &entity.User{ID: "user-id", Email: "test@example.com", Role: entity.RoleUser}
+	mockUserUseCase.On("VerifyCredentials", ctx, "test@example.com", "password123").Return(user, nil)
+	mockTokenRepo.On("Create", ctx, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+		return token.UserID == "user-id" && token.FamilyID != "" && len(token.TokenHash) == 64
+	})).Return(nil)
+
+	tokens, err := useCase.Login(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, auth.TokenType, tokens.TokenType)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	principal, err := tokenManager.ParseAccessToken(tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-id", principal.UserID)
+	assert.Equal(t, entity.RoleUser, principal.Role)
+	assert.True(t, principal.HasPermission(entity.PermissionUsersRead))
+	assert.False(t, principal.HasPermission(entity.PermissionUsersDelete))
+
+	mockUserUseCase.AssertExpectations(t)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Login_InvalidCredentials(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	mockUserUseCase.On("VerifyCredentials", ctx, "test@example.com", "wrong").Return(nil, entity.ErrInvalidCredentials)
+
+	_, err := useCase.Login(ctx, "test@example.com", "wrong")
+	assert.ErrorIs(t, err, entity.ErrInvalidCredentials)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_Refresh_RotatesToken(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	current := &entity.RefreshToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		FamilyID:  "family-id",
+		TokenHash: auth.HashRefreshToken("raw-token"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	user := &entity.User{ID: "user-id", Email: "test@example.com", Role: entity.RoleUser}
+
+	var newTokenID string
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("raw-token")).Return(current, nil)
+	mockUserUseCase.On("GetUserByID", ctx, "user-id").Return(user, nil)
+	mockTokenRepo.On("Revoke", ctx, "token-id", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { newTokenID = args.String(2) }).
+		Return(nil)
+	mockTokenRepo.On("Create", ctx, mock.MatchedBy(func(token *entity.RefreshToken) bool {
+		return token.ID == newTokenID && token.FamilyID == "family-id"
+	})).Return(nil)
+
+	tokens, err := useCase.Refresh(ctx, "raw-token")
+	require.NoError(t, err)
+	assert.NotEqual(t, "raw-token", tokens.RefreshToken)
+
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Refresh_ReuseRevokesFamily(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	revokedAt := time.Now().Add(-time.Minute)
+	rotated := &entity.RefreshToken{
+		ID:         "token-id",
+		UserID:     "user-id",
+		FamilyID:   "family-id",
+		ExpiresAt:  time.Now().Add(time.Hour),
+		RevokedAt:  &revokedAt,
+		ReplacedBy: "next-token-id",
+	}
+
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("stolen-token")).Return(rotated, nil)
+	mockTokenRepo.On("RevokeFamily", ctx, "family-id").Return(nil)
+
+	_, err := useCase.Refresh(ctx, "stolen-token")
+	assert.ErrorIs(t, err, entity.ErrRefreshTokenReused)
+
+	mockTokenRepo.AssertExpectations(t)
+	mockUserUseCase.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_Refresh_ConcurrentRotation(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	transactor := newTestTransactor()
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), transactor, newTestTokenManager(t))
+	ctx := context.Background()
+
+	current := &entity.RefreshToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		FamilyID:  "family-id",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	// Request lain sudah merotasi token ini lebih dulu
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("raw-token")).Return(current, nil)
+	mockUserUseCase.On("GetUserByID", ctx, "user-id").Return(&entity.User{ID: "user-id", Role: entity.RoleUser}, nil)
+	mockTokenRepo.On("Revoke", mock.Anything, "token-id", mock.AnythingOfType("string")).Return(entity.ErrRefreshTokenReused)
+	mockTokenRepo.On("RevokeFamily", ctx, "family-id").Return(nil)
+
+	_, err := useCase.Refresh(ctx, "raw-token")
+	assert.ErrorIs(t, err, entity.ErrRefreshTokenReused)
+
+	transactor.AssertNumberOfCalls(t, "WithTransaction", 1)
+	mockTokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_Refresh_Expired(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	expired := &entity.RefreshToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		FamilyID:  "family-id",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("raw-token")).Return(expired, nil)
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("unknown-token")).Return(nil, entity.ErrRefreshTokenNotFound)
+
+	_, err := useCase.Refresh(ctx, "raw-token")
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+
+	_, err = useCase.Refresh(ctx, "unknown-token")
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+}
+
+func TestAuthUseCase_Refresh_LoggedOut(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	// Token yang dicabut karena logout tidak memiliki pengganti, sehingga
+	// bukan reuse dan family tidak perlu dicabut lagi
+	revokedAt := time.Now().Add(-time.Minute)
+	loggedOut := &entity.RefreshToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		FamilyID:  "family-id",
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("raw-token")).Return(loggedOut, nil)
+
+	_, err := useCase.Refresh(ctx, "raw-token")
+	assert.ErrorIs(t, err, entity.ErrInvalidRefreshToken)
+
+	mockTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+	mockUserUseCase.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	mockUserUseCase := new(MockUserUseCase)
+	mockTokenRepo := new(MockRefreshTokenRepository)
+	useCase := NewAuthUseCase(mockUserUseCase, mockTokenRepo, newTestRoleRepository(), newTestTransactor(), newTestTokenManager(t))
+	ctx := context.Background()
+
+	current := &entity.RefreshToken{ID: "token-id", FamilyID: "family-id"}
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("raw-token")).Return(current, nil)
+	mockTokenRepo.On("GetByTokenHash", ctx, auth.HashRefreshToken("unknown-token")).Return(nil, entity.ErrRefreshTokenNotFound)
+	mockTokenRepo.On("RevokeFamily", ctx, "family-id").Return(nil)
+
+	require.NoError(t, useCase.Logout(ctx, "raw-token"))
+	require.NoError(t, useCase.Logout(ctx, "unknown-token"))
+
+	mockTokenRepo.AssertNumberOfCalls(t, "RevokeFamily", 1)
+}
@@ -0,0 +1,13 @@
+package usecase_interface
+
+import (
+	"context"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+)
+
+type AuthUseCase interface {
+	Login(ctx context.Context, email, password string) (*entity.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*entity.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
@@ -2,14 +2,26 @@ package usecase_interface
 
 import (
 	"context"
+	"time"
 
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
 )
 
+// UserUseCase adalah usecase untuk user. CreateUser, UpdateUser dan
+// PatchUser mengembalikan entity.ErrForbidden jika principal pada context
+// bukan admin tetapi meminta role yang berbeda dari role user saat ini
+// (entity.DefaultRole untuk user baru).
 type UserUseCase interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
 	UpdateUser(ctx context.Context, user *entity.User) error
+	PatchUser(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error)
 	DeleteUser(ctx context.Context, id string) error
-	ListUsers(ctx context.Context, offset, limit int) ([]*entity.User, error)
+	RestoreUser(ctx context.Context, id string) (*entity.User, error)
+	PurgeDeletedUsers(ctx context.Context, retention time.Duration) (int64, error)
+	ListUsers(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error)
+	ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error)
+	VerifyCredentials(ctx context.Context, email, password string) (*entity.User, error)
+	IsEmailTaken(ctx context.Context, email, excludeID string) (bool, error)
 }
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer dipakai untuk span setiap method usecase
+var tracer = otel.Tracer("github.com/sekolahmu/boilerplate-go/internal/usecase")
+
+// startSpan membuat child span dari span handler pada ctx. Tanpa span yang
+// di-sampling, misalnya pada background job, ctx dikembalikan apa adanya
+// beserta span no-op sehingga tidak ada trace baru yang dibuat.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, parent
+	}
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan mengakhiri span dan mencatat err. Error domain seperti user tidak
+// ditemukan dicatat sebagai event tanpa menandai span gagal; hanya error
+// internal yang membuat status span menjadi Error. Dipanggil dengan defer
+// sehingga membutuhkan pointer ke named return err.
+func endSpan(span trace.Span, err *error) {
+	if !span.IsRecording() {
+		return
+	}
+	if *err != nil {
+		span.RecordError(*err)
+		if apperror.KindOf(*err) == apperror.KindInternal {
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+	}
+	span.End()
+}
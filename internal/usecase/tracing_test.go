@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/sekolahmu/boilerplate-go/pkg/hasher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUserUseCase_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+
+	var repoSpan trace.SpanContext
+	mockRepo.On("GetByID", mock.Anything, "test-id").
+		Run(func(args mock.Arguments) {
+			repoSpan = trace.SpanContextFromContext(args.Get(0).(context.Context))
+		}).
+		Return(&entity.User{ID: "test-id"}, nil)
+	mockRepo.On("GetByID", mock.Anything, "unknown-id").Return(nil, entity.ErrUserNotFound)
+	mockRepo.On("GetByID", mock.Anything, "broken-id").Return(nil, errors.New("connection refused"))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "handler")
+	_, err := useCase.GetUserByID(ctx, "test-id")
+	require.NoError(t, err)
+	_, err = useCase.GetUserByID(ctx, "unknown-id")
+	require.Error(t, err)
+	_, err = useCase.GetUserByID(ctx, "broken-id")
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+
+	// Repository menerima context dari span usecase, bukan span handler
+	assert.Equal(t, "UserUseCase.GetUserByID", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), repoSpan.SpanID())
+
+	// Error domain tidak menandai span gagal, error internal menandai
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
@@ -2,62 +2,261 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
 	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
 	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/hasher"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 )
 
 type userUseCase struct {
-	userRepo repoInterface.Repository[entity.User]
+	userRepo       repoInterface.UserRepository
+	passwordHasher hasher.PasswordHasher
+}
+
+// userIDAttr adalah atribut span untuk id user yang diproses
+func userIDAttr(id string) attribute.KeyValue {
+	return attribute.String("user.id", id)
 }
 
 // NewUserUseCase membuat instance baru dari UserUseCase
-func NewUserUseCase(userRepo repoInterface.Repository[entity.User]) usecase_interface.UserUseCase {
+func NewUserUseCase(userRepo repoInterface.UserRepository, passwordHasher hasher.PasswordHasher) usecase_interface.UserUseCase {
 	return &userUseCase{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
 	}
 }
 
-func (uc *userUseCase) CreateUser(ctx context.Context, user *entity.User) error {
+func (uc *userUseCase) CreateUser(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.CreateUser")
+	defer endSpan(span, &err)
+
+	if err := authorizeRoleChange(ctx, entity.DefaultRole, user.Role); err != nil {
+		return err
+	}
+
+	hash, err := uc.passwordHasher.Hash(user.Password)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
 	user.ID = uuid.New().String()
+	user.Password = hash
+	if user.Role == "" {
+		user.Role = entity.DefaultRole
+	}
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
 	return uc.userRepo.Create(ctx, user)
 }
 
-func (uc *userUseCase) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+func (uc *userUseCase) GetUserByID(ctx context.Context, id string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.GetUserByID", userIDAttr(id))
+	defer endSpan(span, &err)
+
 	return uc.userRepo.GetByID(ctx, id)
 }
 
-func (uc *userUseCase) UpdateUser(ctx context.Context, user *entity.User) error {
-	existingUser, err := uc.userRepo.GetByID(ctx, user.ID)
-	if err != nil {
-		return err
+// UpdateUser mengupdate user. Password dan role kosong berarti tidak diubah;
+// repository mengembalikan entity.ErrUserNotFound jika user tidak ada.
+func (uc *userUseCase) UpdateUser(ctx context.Context, user *entity.User) (err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.UpdateUser", userIDAttr(user.ID))
+	defer endSpan(span, &err)
+
+	if user.Role != "" {
+		if err := uc.authorizeUserRole(ctx, user.ID, user.Role); err != nil {
+			return err
+		}
 	}
-	if existingUser == nil {
-		return entity.ErrUserNotFound
+
+	if user.Password != "" {
+		hash, err := uc.passwordHasher.Hash(user.Password)
+		if err != nil {
+			return fmt.Errorf("error hashing password: %w", err)
+		}
+		user.Password = hash
 	}
 
 	user.UpdatedAt = time.Now()
 	return uc.userRepo.Update(ctx, user)
 }
 
-func (uc *userUseCase) DeleteUser(ctx context.Context, id string) error {
-	existingUser, err := uc.userRepo.GetByID(ctx, id)
+// PatchUser hanya mengubah field yang dikirim. Patch kosong mengembalikan
+// user apa adanya tanpa menulis ke database.
+func (uc *userUseCase) PatchUser(ctx context.Context, id string, patch entity.UserPatch) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.PatchUser", userIDAttr(id))
+	defer endSpan(span, &err)
+
+	if patch.IsEmpty() {
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if patch.Version != 0 && patch.Version != user.Version {
+			return nil, entity.ErrUserVersionConflict
+		}
+		return user, nil
+	}
+
+	if patch.Role != nil {
+		if err := uc.authorizeUserRole(ctx, id, *patch.Role); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.Password != nil {
+		hash, err := uc.passwordHasher.Hash(*patch.Password)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing password: %w", err)
+		}
+		patch.Password = &hash
+	}
+
+	patch.UpdatedAt = time.Now()
+	return uc.userRepo.Patch(ctx, id, patch)
+}
+
+// DeleteUser melakukan soft delete; user dapat dikembalikan dengan RestoreUser
+// sampai dihapus permanen oleh PurgeDeletedUsers
+// authorizeUserRole memeriksa role yang diminta terhadap role user saat ini
+func (uc *userUseCase) authorizeUserRole(ctx context.Context, id, role string) error {
+	current, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if existingUser == nil {
-		return entity.ErrUserNotFound
+	return authorizeRoleChange(ctx, current.Role, role)
+}
+
+// authorizeRoleChange hanya mengizinkan admin mengubah role. Role kosong atau
+// sama dengan role saat ini bukan perubahan, sehingga user yang mengirim
+// ulang role miliknya pada PUT tidak ditolak.
+func authorizeRoleChange(ctx context.Context, current, requested string) error {
+	if requested == "" || requested == current {
+		return nil
 	}
+	if principal, ok := auth.FromContext(ctx); ok && principal.IsAdmin() {
+		return nil
+	}
+	return entity.ErrForbidden
+}
+
+func (uc *userUseCase) DeleteUser(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.DeleteUser", userIDAttr(id))
+	defer endSpan(span, &err)
 
 	return uc.userRepo.Delete(ctx, id)
 }
 
-func (uc *userUseCase) ListUsers(ctx context.Context, offset, limit int) ([]*entity.User, error) {
-	return uc.userRepo.List(ctx, offset, limit)
+func (uc *userUseCase) RestoreUser(ctx context.Context, id string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.RestoreUser", userIDAttr(id))
+	defer endSpan(span, &err)
+
+	return uc.userRepo.Restore(ctx, id)
+}
+
+// PurgeDeletedUsers menghapus permanen user yang sudah di-soft delete lebih
+// lama dari retention
+func (uc *userUseCase) PurgeDeletedUsers(ctx context.Context, retention time.Duration) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.PurgeDeletedUsers")
+	defer endSpan(span, &err)
+
+	return uc.userRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+}
+
+func (uc *userUseCase) ListUsers(ctx context.Context, query repoInterface.ListQuery) (_ []*entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.ListUsers")
+	defer endSpan(span, &err)
+
+	return uc.userRepo.List(ctx, query)
+}
+
+func (uc *userUseCase) ListUsersPage(ctx context.Context, query repoInterface.PageQuery) (_ *repoInterface.Page[entity.User], err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.ListUsersPage")
+	defer endSpan(span, &err)
+
+	return uc.userRepo.ListPage(ctx, query)
+}
+
+// IsEmailTaken memeriksa apakah email sudah dipakai oleh user lain.
+// excludeID diisi dengan ID user yang sedang diupdate agar email miliknya
+// sendiri tidak dianggap bentrok.
+func (uc *userUseCase) IsEmailTaken(ctx context.Context, email, excludeID string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.IsEmailTaken")
+	defer endSpan(span, &err)
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.ID != excludeID, nil
+}
+
+// VerifyCredentials memeriksa email dan password, dan melakukan rehash secara
+// transparan jika hash tersimpan dibuat dengan algoritma atau parameter lama
+func (uc *userUseCase) VerifyCredentials(ctx context.Context, email, password string) (_ *entity.User, err error) {
+	ctx, span := startSpan(ctx, "UserUseCase.VerifyCredentials")
+	defer endSpan(span, &err)
+
+	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		// Tetap lakukan hashing agar waktu respons tidak membocorkan
+		// apakah email terdaftar atau tidak
+		_, _ = uc.passwordHasher.Hash(password)
+		return nil, entity.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := uc.passwordHasher.Verify(password, user.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying password: %w", err)
+	}
+	if !ok {
+		return nil, entity.ErrInvalidCredentials
+	}
+
+	if uc.passwordHasher.NeedsRehash(user.Password) {
+		uc.rehashPassword(ctx, user, password)
+	}
+
+	return user, nil
+}
+
+// rehashPassword menyimpan hash baru untuk password yang sudah terverifikasi.
+// Kegagalan hanya dicatat di log karena hash lama masih valid, sehingga login
+// tidak gagal karena rehash.
+func (uc *userUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	log := logger.FromContext(ctx).With(zap.String("user_id", user.ID))
+
+	hash, err := uc.passwordHasher.Hash(password)
+	if err != nil {
+		log.Warn("error rehashing password", zap.Error(err))
+		return
+	}
+	updated, err := uc.userRepo.UpdatePasswordHash(ctx, user.ID, user.Password, hash)
+	if err != nil {
+		log.Warn("error storing rehashed password", zap.Error(err))
+		return
+	}
+	if !updated {
+		// Password diganti bersamaan dengan login ini
+		log.Info("password changed before rehash was stored")
+		return
+	}
+
+	user.Password = hash
+	log.Info("password rehashed")
 }
@@ -2,13 +2,20 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/sekolahmu/boilerplate-go/internal/auth"
 	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	repoInterface "github.com/sekolahmu/boilerplate-go/internal/repository/interface"
+	"github.com/sekolahmu/boilerplate-go/internal/usecase/usecase_interface"
+	"github.com/sekolahmu/boilerplate-go/pkg/hasher"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // MockUserRepository adalah mock untuk UserRepository
@@ -29,29 +36,105 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*entity.Us
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *entity.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) Patch(ctx context.Context, id string, patch entity.UserPatch) (*entity.User, error) {
+	args := m.Called(ctx, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id string) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, id, currentHash, newHash string) (bool, error) {
+	args := m.Called(ctx, id, currentHash, newHash)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) List(ctx context.Context, offset, limit int) ([]*entity.User, error) {
-	args := m.Called(ctx, offset, limit)
+func (m *MockUserRepository) List(ctx context.Context, query repoInterface.ListQuery) ([]*entity.User, error) {
+	args := m.Called(ctx, query)
 	return args.Get(0).([]*entity.User), args.Error(1)
 }
 
-func (m *MockUserRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+func (m *MockUserRepository) ListPage(ctx context.Context, query repoInterface.PageQuery) (*repoInterface.Page[entity.User], error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterface.Page[entity.User]), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context, query repoInterface.ListQuery) (int64, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoInterface.TxOption) error {
 	args := m.Called(ctx, fn)
 	return args.Error(0)
 }
 
+// MockTransactor menjalankan fn secara langsung tanpa transaksi
+type MockTransactor struct {
+	mock.Mock
+}
+
+func (m *MockTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...repoInterface.TxOption) error {
+	m.Called(ctx)
+	return fn(ctx)
+}
+
+func newTestTransactor() *MockTransactor {
+	transactor := new(MockTransactor)
+	transactor.On("WithTransaction", mock.Anything)
+	return transactor
+}
+
+func newTestHasher(t *testing.T, algorithm string) hasher.PasswordHasher {
+	h, err := hasher.New(hasher.Options{
+		Algorithm:         algorithm,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+	return h
+}
+
 func TestUserUseCase_CreateUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	passwordHasher := newTestHasher(t, hasher.AlgorithmArgon2id)
+	useCase := NewUserUseCase(mockRepo, passwordHasher)
 	ctx := context.Background()
 
 	user := &entity.User{
@@ -67,13 +150,20 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 	assert.NotEmpty(t, user.ID)
 	assert.NotZero(t, user.CreatedAt)
 	assert.NotZero(t, user.UpdatedAt)
+	assert.Equal(t, entity.DefaultRole, user.Role)
+
+	// Password tidak boleh disimpan dalam bentuk plaintext
+	assert.NotEqual(t, "password123", user.Password)
+	ok, err := passwordHasher.Verify("password123", user.Password)
+	require.NoError(t, err)
+	assert.True(t, ok)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUserUseCase_GetUserByID(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
 	ctx := context.Background()
 
 	expectedUser := &entity.User{
@@ -87,16 +177,22 @@ func TestUserUseCase_GetUserByID(t *testing.T) {
 
 	mockRepo.On("GetByID", ctx, "test-id").Return(expectedUser, nil)
 
+	mockRepo.On("GetByID", ctx, "unknown-id").Return(nil, entity.ErrUserNotFound)
+
 	user, err := useCase.GetUserByID(ctx, "test-id")
 	require.NoError(t, err)
 	assert.Equal(t, expectedUser, user)
 
+	user, err = useCase.GetUserByID(ctx, "unknown-id")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+	assert.Nil(t, user)
+
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUserUseCase_UpdateUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
 	ctx := context.Background()
 
 	existingUser := &entity.User{
@@ -117,42 +213,220 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	mockRepo.On("GetByID", ctx, "test-id").Return(existingUser, nil)
 	mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(nil)
 
 	err := useCase.UpdateUser(ctx, updatedUser)
 	require.NoError(t, err)
 	assert.NotEqual(t, existingUser.UpdatedAt, updatedUser.UpdatedAt)
+	assert.NotEqual(t, "newpassword", updatedUser.Password)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestUserUseCase_DeleteUser(t *testing.T) {
+func TestUserUseCase_UpdateUser_KeepsPasswordWhenEmpty(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
 	ctx := context.Background()
 
-	existingUser := &entity.User{
-		ID:        "test-id",
-		Email:     "test@example.com",
-		Name:      "Test User",
-		Password:  "password123",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	updatedUser := &entity.User{
+		ID:    "test-id",
+		Email: "test@example.com",
+		Name:  "Updated User",
 	}
 
+	// Password kosong diteruskan apa adanya agar repository mempertahankan
+	// hash yang tersimpan
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(u *entity.User) bool {
+		return u.Password == ""
+	})).Return(nil)
+
+	err := useCase.UpdateUser(ctx, updatedUser)
+	require.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_PatchUser(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	name := "Patched Name"
+	password := "NewSecret123"
+	patchedUser := &entity.User{ID: "test-id", Name: name}
+
+	// Hanya field yang dikirim yang diteruskan, dan password di-hash lebih dulu
+	mockRepo.On("Patch", ctx, "test-id", mock.MatchedBy(func(p entity.UserPatch) bool {
+		return p.Email == nil && p.Role == nil &&
+			p.Name != nil && *p.Name == name &&
+			p.Password != nil && strings.HasPrefix(*p.Password, "$argon2id$") &&
+			!p.UpdatedAt.IsZero()
+	})).Return(patchedUser, nil)
+
+	user, err := useCase.PatchUser(ctx, "test-id", entity.UserPatch{Name: &name, Password: &password})
+	require.NoError(t, err)
+	assert.Equal(t, patchedUser, user)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_PatchUser_Empty(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	existingUser := &entity.User{ID: "test-id"}
 	mockRepo.On("GetByID", ctx, "test-id").Return(existingUser, nil)
+	mockRepo.On("GetByID", ctx, "unknown-id").Return(nil, entity.ErrUserNotFound)
+
+	user, err := useCase.PatchUser(ctx, "test-id", entity.UserPatch{})
+	require.NoError(t, err)
+	assert.Equal(t, existingUser, user)
+
+	_, err = useCase.PatchUser(ctx, "unknown-id", entity.UserPatch{})
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+	// Patch kosong tetap memeriksa versi yang diharapkan
+	existingUser.Version = 2
+	_, err = useCase.PatchUser(ctx, "test-id", entity.UserPatch{Version: 1})
+	assert.ErrorIs(t, err, entity.ErrUserVersionConflict)
+
+	mockRepo.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserUseCase_CreateUser_RoleRequiresAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+	adminCtx := auth.NewContext(ctx, &auth.Principal{UserID: "admin-id", Role: entity.RoleAdmin})
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+
+	// Role default boleh dikirim tanpa principal, misalnya saat registrasi
+	err := useCase.CreateUser(ctx, &entity.User{Email: "a@example.com", Name: "A", Password: "password123", Role: entity.DefaultRole})
+	require.NoError(t, err)
+
+	err = useCase.CreateUser(ctx, &entity.User{Email: "b@example.com", Name: "B", Password: "password123", Role: entity.RoleAdmin})
+	assert.ErrorIs(t, err, entity.ErrForbidden)
+
+	err = useCase.CreateUser(adminCtx, &entity.User{Email: "c@example.com", Name: "C", Password: "password123", Role: entity.RoleAdmin})
+	require.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestUserUseCase_UpdateUser_RoleRequiresAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := auth.NewContext(context.Background(), &auth.Principal{UserID: "test-id", Role: entity.RoleUser})
+	adminCtx := auth.NewContext(context.Background(), &auth.Principal{UserID: "admin-id", Role: entity.RoleAdmin})
+
+	mockRepo.On("GetByID", mock.Anything, "test-id").Return(&entity.User{ID: "test-id", Role: entity.RoleUser}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.User")).Return(nil)
+
+	// Mengirim ulang role saat ini bukan perubahan role
+	err := useCase.UpdateUser(ctx, &entity.User{ID: "test-id", Email: "test@example.com", Name: "Test", Role: entity.RoleUser})
+	require.NoError(t, err)
+
+	err = useCase.UpdateUser(ctx, &entity.User{ID: "test-id", Email: "test@example.com", Name: "Test", Role: entity.RoleAdmin})
+	assert.ErrorIs(t, err, entity.ErrForbidden)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+
+	err = useCase.UpdateUser(adminCtx, &entity.User{ID: "test-id", Email: "test@example.com", Name: "Test", Role: entity.RoleAdmin})
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestUserUseCase_PatchUser_RoleRequiresAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := auth.NewContext(context.Background(), &auth.Principal{UserID: "test-id", Role: entity.RoleUser})
+
+	mockRepo.On("GetByID", mock.Anything, "test-id").Return(&entity.User{ID: "test-id", Role: entity.RoleUser}, nil)
+	mockRepo.On("Patch", mock.Anything, "test-id", mock.AnythingOfType("entity.UserPatch")).Return(&entity.User{ID: "test-id"}, nil)
+
+	sameRole := entity.RoleUser
+	_, err := useCase.PatchUser(ctx, "test-id", entity.UserPatch{Role: &sameRole})
+	require.NoError(t, err)
+
+	adminRole := entity.RoleAdmin
+	_, err = useCase.PatchUser(ctx, "test-id", entity.UserPatch{Role: &adminRole})
+	assert.ErrorIs(t, err, entity.ErrForbidden)
+
+	mockRepo.AssertNumberOfCalls(t, "Patch", 1)
+}
+
+func TestUserUseCase_DeleteUser(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
 	mockRepo.On("Delete", ctx, "test-id").Return(nil)
+	mockRepo.On("Delete", ctx, "unknown-id").Return(entity.ErrUserNotFound)
 
 	err := useCase.DeleteUser(ctx, "test-id")
 	require.NoError(t, err)
 
+	err = useCase.DeleteUser(ctx, "unknown-id")
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestUserUseCase_UpdateUser_NotFound(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.User")).Return(entity.ErrUserNotFound)
+
+	err := useCase.UpdateUser(ctx, &entity.User{ID: "unknown-id", Email: "test@example.com", Name: "Test"})
+	assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestUserUseCase_RestoreUser(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	restoredUser := &entity.User{ID: "test-id"}
+	mockRepo.On("Restore", ctx, "test-id").Return(restoredUser, nil)
+	mockRepo.On("Restore", ctx, "active-id").Return(nil, entity.ErrUserNotDeleted)
+
+	user, err := useCase.RestoreUser(ctx, "test-id")
+	require.NoError(t, err)
+	assert.Equal(t, restoredUser, user)
+
+	_, err = useCase.RestoreUser(ctx, "active-id")
+	assert.ErrorIs(t, err, entity.ErrUserNotDeleted)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_PurgeDeletedUsers(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	retention := 30 * 24 * time.Hour
+	mockRepo.On("PurgeDeleted", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before.Add(retention)) < time.Minute
+	})).Return(int64(2), nil)
+
+	purged, err := useCase.PurgeDeletedUsers(ctx, retention)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUserUseCase_ListUsers(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
 	ctx := context.Background()
 
 	expectedUsers := []*entity.User{
@@ -174,11 +448,110 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("List", ctx, 0, 10).Return(expectedUsers, nil)
+	query := repoInterface.ListQuery{
+		Filters: []repoInterface.Filter{{Field: "email", Operator: repoInterface.OpPrefix, Value: "user"}},
+		Offset:  0,
+		Limit:   10,
+	}
+	mockRepo.On("List", ctx, query).Return(expectedUsers, nil)
 
-	users, err := useCase.ListUsers(ctx, 0, 10)
+	users, err := useCase.ListUsers(ctx, query)
 	require.NoError(t, err)
 	assert.Equal(t, expectedUsers, users)
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUserUseCase_IsEmailTaken(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	useCase := NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	ctx := context.Background()
+
+	existingUser := &entity.User{ID: "test-id", Email: "test@example.com"}
+	mockRepo.On("GetByEmail", ctx, "test@example.com").Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "free@example.com").Return(nil, entity.ErrUserNotFound)
+
+	taken, err := useCase.IsEmailTaken(ctx, "test@example.com", "")
+	require.NoError(t, err)
+	assert.True(t, taken)
+
+	// Email milik user itu sendiri tidak dianggap bentrok
+	taken, err = useCase.IsEmailTaken(ctx, "test@example.com", "test-id")
+	require.NoError(t, err)
+	assert.False(t, taken)
+
+	taken, err = useCase.IsEmailTaken(ctx, "free@example.com", "")
+	require.NoError(t, err)
+	assert.False(t, taken)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_VerifyCredentials(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	passwordHasher := newTestHasher(t, hasher.AlgorithmArgon2id)
+	useCase := NewUserUseCase(mockRepo, passwordHasher)
+	ctx := context.Background()
+
+	hash, err := passwordHasher.Hash("password123")
+	require.NoError(t, err)
+	existingUser := &entity.User{
+		ID:       "test-id",
+		Email:    "test@example.com",
+		Password: hash,
+	}
+
+	mockRepo.On("GetByEmail", ctx, "test@example.com").Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, entity.ErrUserNotFound)
+
+	user, err := useCase.VerifyCredentials(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "test-id", user.ID)
+
+	_, err = useCase.VerifyCredentials(ctx, "test@example.com", "wrong-password")
+	assert.ErrorIs(t, err, entity.ErrInvalidCredentials)
+
+	_, err = useCase.VerifyCredentials(ctx, "unknown@example.com", "password123")
+	assert.ErrorIs(t, err, entity.ErrInvalidCredentials)
+
+	// Hash masih valid sehingga tidak ada rehash
+	mockRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserUseCase_VerifyCredentials_Rehash(t *testing.T) {
+	ctx := context.Background()
+
+	// Hash lama dibuat dengan bcrypt
+	legacyHash, err := newTestHasher(t, hasher.AlgorithmBcrypt).Hash("password123")
+	require.NoError(t, err)
+	newHash := mock.MatchedBy(func(hash string) bool { return strings.Contains(hash, "$argon2id$") })
+
+	setup := func(updated bool, err error) (*MockUserRepository, usecase_interface.UserUseCase) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("GetByEmail", ctx, "test@example.com").
+			Return(&entity.User{ID: "test-id", Email: "test@example.com", Password: legacyHash, Version: 3}, nil)
+		mockRepo.On("UpdatePasswordHash", ctx, "test-id", legacyHash, newHash).Return(updated, err)
+		return mockRepo, NewUserUseCase(mockRepo, newTestHasher(t, hasher.AlgorithmArgon2id))
+	}
+
+	mockRepo, useCase := setup(true, nil)
+	user, err := useCase.VerifyCredentials(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+	assert.Contains(t, user.Password, "$argon2id$")
+	mockRepo.AssertExpectations(t)
+	// Rehash tidak memakai update dengan pengecekan versi
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	// Kegagalan menyimpan rehash tidak membuat login gagal
+	mockRepo, useCase = setup(false, errors.New("connection reset"))
+	user, err = useCase.VerifyCredentials(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, legacyHash, user.Password)
+	mockRepo.AssertExpectations(t)
+
+	// Password sudah diganti oleh request lain
+	mockRepo, useCase = setup(false, nil)
+	user, err = useCase.VerifyCredentials(ctx, "test@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, legacyHash, user.Password)
+}
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access to every user'),
+    ('user', 'Access to own account only')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:create', 'Create users'),
+    ('users:read', 'Read a user'),
+    ('users:list', 'List users'),
+    ('users:update', 'Update a user'),
+    ('users:delete', 'Delete a user')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:create'),
+    ('admin', 'users:read'),
+    ('admin', 'users:list'),
+    ('admin', 'users:update'),
+    ('admin', 'users:delete'),
+    ('user', 'users:read'),
+    ('user', 'users:update')
+ON CONFLICT (role, permission) DO NOTHING;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(50) NOT NULL DEFAULT 'user' REFERENCES roles(name);
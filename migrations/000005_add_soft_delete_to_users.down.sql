@@ -0,0 +1,19 @@
+-- Rollback dihentikan jika masih ada user yang di-soft delete. Menghapus kolom
+-- deleted_at akan memunculkan kembali user tersebut sebagai user aktif (dan
+-- dapat melanggar users_email_key), sedangkan menghapus barisnya akan
+-- membuang data. Pulihkan atau hapus permanen user tersebut terlebih dahulu.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users WHERE deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'cannot roll back 000005: users table still has soft-deleted rows; restore or purge them first';
+    END IF;
+END
+$$;
+
+DELETE FROM role_permissions WHERE permission = 'users:restore';
+DELETE FROM permissions WHERE name = 'users:restore';
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS users_email_active_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+// Package migrations menyimpan file migrasi SQL yang di-embed ke dalam binary
+// sehingga migrasi dapat dijalankan tanpa CLI migrate eksternal
+package migrations
+
+import "embed"
+
+// FS berisi semua file migrasi dengan format
+// <versi>_<nama>.up.sql dan <versi>_<nama>.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
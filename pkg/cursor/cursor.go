@@ -0,0 +1,66 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Codec mengenkode nilai cursor menjadi string opaque yang ditandatangani
+// dengan HMAC-SHA256, sehingga client tidak dapat membuat atau mengubah cursor
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec membuat Codec baru. Secret harus sama di semua replika agar
+// cursor dapat dipakai lintas instance.
+func NewCodec(secret string) (*Codec, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("cursor secret must be at least 32 bytes")
+	}
+	return &Codec{secret: []byte(secret)}, nil
+}
+
+// Encode mengubah v menjadi "<payload>.<signature>" dalam base64url
+func (c *Codec) Encode(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("error encoding cursor: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded)), nil
+}
+
+// Decode memverifikasi tanda tangan cursor lalu mengisi v
+func (c *Codec) Decode(token string, v any) error {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return ErrInvalidCursor
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, c.sign(encoded)) {
+		return ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (c *Codec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
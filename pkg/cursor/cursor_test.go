@@ -0,0 +1,60 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type position struct {
+	CreatedAt string `json:"t"`
+	ID        string `json:"i"`
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	codec, err := NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	token, err := codec.Encode(position{CreatedAt: "2024-01-01T00:00:00Z", ID: "user-id"})
+	require.NoError(t, err)
+	assert.NotContains(t, token, "user-id")
+
+	var decoded position
+	require.NoError(t, codec.Decode(token, &decoded))
+	assert.Equal(t, position{CreatedAt: "2024-01-01T00:00:00Z", ID: "user-id"}, decoded)
+}
+
+func TestCodec_RejectsTampering(t *testing.T) {
+	codec, err := NewCodec("test-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+	other, err := NewCodec("another-secret-that-is-at-least-32-bytes")
+	require.NoError(t, err)
+
+	token, err := codec.Encode(position{ID: "user-id"})
+	require.NoError(t, err)
+
+	forged, err := other.Encode(position{ID: "other-id"})
+	require.NoError(t, err)
+
+	payload, signature, _ := strings.Cut(token, ".")
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, invalid := range []string{
+		"",
+		"garbage",
+		forged,
+		forgedPayload + "." + signature,
+		payload + ".",
+		payload + "." + signature + "x",
+	} {
+		var decoded position
+		assert.ErrorIs(t, codec.Decode(invalid, &decoded), ErrInvalidCursor, invalid)
+	}
+}
+
+func TestNewCodec_RejectsShortSecret(t *testing.T) {
+	_, err := NewCodec("short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Backoff mengatur jeda antar percobaan ping saat startup. Jeda dimulai dari
+// Initial, dikali dua setiap percobaan, dan tidak melebihi Max.
+type Backoff struct {
+	Attempts int
+	Initial  time.Duration
+	Max      time.Duration
+}
+
+// DefaultBackoff dipakai oleh Open. Jumlah percobaan diambil dari
+// config.DatabaseConfig.ConnectAttempts.
+var DefaultBackoff = Backoff{Initial: 500 * time.Millisecond, Max: 10 * time.Second}
+
+// Pinger adalah koneksi yang dapat diperiksa, misalnya *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// DSN membentuk connection string lib/pq dari konfigurasi. ConnectTimeout
+// dan StatementTimeout dikirim sebagai parameter koneksi sehingga berlaku
+// untuk setiap koneksi di pool.
+func DSN(cfg config.DatabaseConfig) string {
+	params := [][2]string{
+		{"host", cfg.Host},
+		{"port", cfg.Port},
+		{"user", cfg.Username},
+		{"password", cfg.Password},
+		{"dbname", cfg.DBName},
+		{"sslmode", cfg.SSLMode},
+	}
+	if cfg.ConnectTimeout > 0 {
+		params = append(params, [2]string{"connect_timeout", strconv.Itoa(cfg.ConnectTimeout)})
+	}
+	if cfg.StatementTimeout > 0 {
+		// statement_timeout PostgreSQL dalam milidetik
+		params = append(params, [2]string{"statement_timeout", strconv.Itoa(cfg.StatementTimeout * 1000)})
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p[1] == "" {
+			continue
+		}
+		parts = append(parts, p[0]+"="+quote(p[1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quote meng-escape nilai yang mengandung spasi, kutip, atau backslash
+// sesuai format key=value libpq
+func quote(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+// Configure menerapkan pengaturan connection pool ke db. Nilai 0 mengikuti
+// default database/sql (tidak dibatasi).
+func Configure(db *sql.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
+	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+}
+
+// Open membuka connection pool, menerapkan pengaturan pool, lalu memastikan
+// database dapat dihubungi sebelum server mulai menerima request
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open(cfg.Driver, DSN(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+	Configure(db, cfg)
+
+	backoff := DefaultBackoff
+	backoff.Attempts = cfg.ConnectAttempts
+	if err := Ping(ctx, db, backoff); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("database %s at %s:%s is unreachable: %w", cfg.DBName, cfg.Host, cfg.Port, err)
+	}
+	return db, nil
+}
+
+// Ping memanggil PingContext sampai berhasil atau percobaan habis, dengan
+// jeda exponential backoff di antara percobaan. Attempts kurang dari 1
+// dianggap satu percobaan.
+func Ping(ctx context.Context, db Pinger, backoff Backoff) error {
+	attempts := backoff.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	wait := backoff.Initial
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = db.PingContext(ctx); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("ping failed after %d attempt(s): %w", attempt, err)
+		}
+
+		logger.FromContext(ctx).Warn("database ping failed, retrying",
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", attempts),
+			zap.Duration("backoff", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("ping canceled after %d attempt(s): %w", attempt, err)
+		case <-timer.C:
+		}
+
+		wait *= 2
+		if backoff.Max > 0 && wait > backoff.Max {
+			wait = backoff.Max
+		}
+	}
+}
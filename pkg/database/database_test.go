@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubConnector adalah driver.Connector yang tidak pernah dipakai untuk query
+type stubConnector struct{}
+
+func (stubConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stubConnector) Driver() driver.Driver { return nil }
+
+// flakyPinger gagal sebanyak failures kali sebelum berhasil
+type flakyPinger struct {
+	failures int
+	calls    int
+}
+
+func (p *flakyPinger) PingContext(ctx context.Context) error {
+	p.calls++
+	if p.calls <= p.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestDSN(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Host:             "localhost",
+		Port:             "5432",
+		Username:         "postgres",
+		Password:         "it's secret",
+		DBName:           "boilerplate",
+		SSLMode:          "disable",
+		ConnectTimeout:   5,
+		StatementTimeout: 30,
+	}
+
+	assert.Equal(t,
+		`host=localhost port=5432 user=postgres password='it\'s secret' dbname=boilerplate sslmode=disable connect_timeout=5 statement_timeout=30000`,
+		DSN(cfg))
+
+	cfg.ConnectTimeout, cfg.StatementTimeout, cfg.Password = 0, 0, ""
+	assert.Equal(t, "host=localhost port=5432 user=postgres dbname=boilerplate sslmode=disable", DSN(cfg))
+}
+
+func TestConfigure(t *testing.T) {
+	db := sql.OpenDB(stubConnector{})
+	defer db.Close()
+
+	Configure(db, config.DatabaseConfig{MaxOpenConns: 20, MaxIdleConns: 5})
+	assert.Equal(t, 20, db.Stats().MaxOpenConnections)
+}
+
+func TestPing(t *testing.T) {
+	backoff := Backoff{Attempts: 3, Initial: time.Millisecond, Max: 2 * time.Millisecond}
+
+	pinger := &flakyPinger{failures: 2}
+	assert.NoError(t, Ping(context.Background(), pinger, backoff))
+	assert.Equal(t, 3, pinger.calls)
+
+	pinger = &flakyPinger{failures: 3}
+	err := Ping(context.Background(), pinger, backoff)
+	assert.EqualError(t, err, "ping failed after 3 attempt(s): connection refused")
+	assert.Equal(t, 3, pinger.calls)
+
+	// Attempts 0 berarti satu percobaan tanpa retry
+	pinger = &flakyPinger{failures: 1}
+	assert.Error(t, Ping(context.Background(), pinger, Backoff{}))
+	assert.Equal(t, 1, pinger.calls)
+}
+
+func TestPing_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pinger := &flakyPinger{failures: 10}
+	err := Ping(ctx, pinger, Backoff{Attempts: 5, Initial: time.Hour})
+	assert.EqualError(t, err, "ping canceled after 1 attempt(s): connection refused")
+	assert.Equal(t, 1, pinger.calls)
+}
@@ -0,0 +1,97 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hash algorithm")
+	ErrInvalidHash          = errors.New("invalid password hash format")
+)
+
+// Options adalah parameter hashing. Field bernilai nol memakai nilai default
+// algoritmanya; Algorithm kosong berarti argon2id.
+type Options struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+}
+
+// PasswordHasher adalah interface untuk hashing dan verifikasi password
+type PasswordHasher interface {
+	// Hash menghasilkan hash dari password menggunakan algoritma yang dikonfigurasi
+	Hash(password string) (string, error)
+	// Verify membandingkan password dengan hash yang tersimpan
+	Verify(password, encodedHash string) (bool, error)
+	// NeedsRehash bernilai true jika hash dibuat dengan algoritma atau parameter yang berbeda
+	NeedsRehash(encodedHash string) bool
+}
+
+// algorithm adalah implementasi satu jenis algoritma hashing
+type algorithm interface {
+	PasswordHasher
+	matches(encodedHash string) bool
+}
+
+type passwordHasher struct {
+	primary    algorithm
+	algorithms []algorithm
+}
+
+// New membuat PasswordHasher berdasarkan opts. Hash baru selalu dibuat
+// dengan algoritma yang dipilih, sedangkan verifikasi mendukung semua
+// algoritma yang dikenal agar hash lama tetap bisa dipakai untuk login.
+func New(opts Options) (PasswordHasher, error) {
+	argon := newArgon2idHasher(opts)
+	bc := newBcryptHasher(opts)
+
+	h := &passwordHasher{algorithms: []algorithm{argon, bc}}
+	switch strings.ToLower(opts.Algorithm) {
+	case "", AlgorithmArgon2id:
+		h.primary = argon
+	case AlgorithmBcrypt:
+		h.primary = bc
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, opts.Algorithm)
+	}
+	return h, nil
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	return h.primary.Hash(password)
+}
+
+func (h *passwordHasher) Verify(password, encodedHash string) (bool, error) {
+	alg, err := h.find(encodedHash)
+	if err != nil {
+		return false, err
+	}
+	return alg.Verify(password, encodedHash)
+}
+
+func (h *passwordHasher) NeedsRehash(encodedHash string) bool {
+	if !h.primary.matches(encodedHash) {
+		return true
+	}
+	return h.primary.NeedsRehash(encodedHash)
+}
+
+func (h *passwordHasher) find(encodedHash string) (algorithm, error) {
+	for _, alg := range h.algorithms {
+		if alg.matches(encodedHash) {
+			return alg, nil
+		}
+	}
+	return nil, ErrInvalidHash
+}
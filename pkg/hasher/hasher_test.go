@@ -0,0 +1,106 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func testArgon2Options() Options {
+	return Options{
+		Algorithm:         AlgorithmArgon2id,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+	}
+}
+
+func TestPasswordHasher_Argon2id(t *testing.T) {
+	h, err := New(testArgon2Options())
+	require.NoError(t, err)
+
+	hash, err := h.Hash("password123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.NotContains(t, hash, "password123")
+
+	ok, err := h.Verify("password123", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("wrong-password", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.False(t, h.NeedsRehash(hash))
+}
+
+func TestPasswordHasher_Bcrypt(t *testing.T) {
+	h, err := New(Options{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	require.NoError(t, err)
+
+	hash, err := h.Hash("password123")
+	require.NoError(t, err)
+
+	ok, err := h.Verify("password123", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("wrong-password", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.False(t, h.NeedsRehash(hash))
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	bcryptHasher, err := New(Options{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	require.NoError(t, err)
+	argonHasher, err := New(testArgon2Options())
+	require.NoError(t, err)
+
+	bcryptHash, err := bcryptHasher.Hash("password123")
+	require.NoError(t, err)
+
+	// Hash bcrypt lama tetap bisa diverifikasi, tetapi perlu di-rehash ke argon2id
+	ok, err := argonHasher.Verify("password123", bcryptHash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, argonHasher.NeedsRehash(bcryptHash))
+
+	// Perubahan parameter argon2id juga memicu rehash
+	cfg := testArgon2Options()
+	cfg.Argon2Iterations = 2
+	strongerHasher, err := New(cfg)
+	require.NoError(t, err)
+
+	argonHash, err := argonHasher.Hash("password123")
+	require.NoError(t, err)
+	assert.True(t, strongerHasher.NeedsRehash(argonHash))
+
+	// Perubahan cost bcrypt memicu rehash
+	costlierHasher, err := New(Options{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	require.NoError(t, err)
+	assert.True(t, costlierHasher.NeedsRehash(bcryptHash))
+}
+
+func TestPasswordHasher_InvalidHash(t *testing.T) {
+	h, err := New(testArgon2Options())
+	require.NoError(t, err)
+
+	_, err = h.Verify("password123", "password123")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+
+	_, err = h.Verify("password123", "$argon2id$v=19$m=1024$broken")
+	assert.ErrorIs(t, err, ErrInvalidHash)
+}
+
+func TestNew_UnsupportedAlgorithm(t *testing.T) {
+	_, err := New(Options{Algorithm: "md5"})
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
@@ -0,0 +1,192 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status adalah hasil sebuah pengecekan
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// ErrShuttingDown dilaporkan oleh readiness selama graceful shutdown
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// CheckFunc memeriksa satu dependency dan mengembalikan error jika tidak sehat
+type CheckFunc func(ctx context.Context) error
+
+// Pinger adalah dependency yang dapat diperiksa, misalnya *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingCheck membuat CheckFunc dari Pinger
+func PingCheck(p Pinger) CheckFunc {
+	return p.PingContext
+}
+
+// Result adalah hasil satu pengecekan
+type Result struct {
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report adalah hasil seluruh pengecekan. Status bernilai down jika salah
+// satu pengecekan gagal.
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Healthy bernilai true jika semua pengecekan berhasil
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// Redacted mengembalikan salinan Report tanpa pesan error, karena error dari
+// driver dapat berisi host, user, atau nama database
+func (r Report) Redacted() Report {
+	redacted := Report{Status: r.Status}
+	if r.Checks != nil {
+		redacted.Checks = make(map[string]Result, len(r.Checks))
+		for name, result := range r.Checks {
+			result.Error = ""
+			redacted.Checks[name] = result
+		}
+	}
+	return redacted
+}
+
+type cachedResult struct {
+	result  Result
+	expires time.Time
+}
+
+// Registry menyimpan pengecekan dependency yang dipakai oleh readiness HTTP
+// dan status health gRPC. Setiap pengecekan dibatasi timeout dan hasilnya
+// di-cache selama cacheTTL agar probe yang sering tidak membebani dependency.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	mu     sync.Mutex
+	checks map[string]CheckFunc
+	cache  map[string]cachedResult
+
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry membuat instance baru dari Registry. cacheTTL 0 berarti
+// hasil pengecekan tidak di-cache.
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+		checks:   make(map[string]CheckFunc),
+		cache:    make(map[string]cachedResult),
+	}
+}
+
+// Register mendaftarkan pengecekan dengan nama yang muncul pada Report
+func (r *Registry) Register(name string, check CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+	delete(r.cache, name)
+}
+
+// Check menjalankan semua pengecekan secara paralel, atau memakai hasil
+// yang masih ada di cache
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+	sort.Strings(names)
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(names))}
+	results := make([]Result, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		i, name := i, name
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, name)
+		}()
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// Ready sama seperti Check, tetapi selalu gagal setelah Shutdown dipanggil
+// agar load balancer berhenti mengirim request selama graceful shutdown
+func (r *Registry) Ready(ctx context.Context) Report {
+	if r.shuttingDown.Load() {
+		return Report{
+			Status: StatusDown,
+			Checks: map[string]Result{
+				"shutdown": {Status: StatusDown, Error: ErrShuttingDown.Error(), Duration: "0s", CheckedAt: time.Now()},
+			},
+		}
+	}
+	return r.Check(ctx)
+}
+
+// Shutdown menandai aplikasi sedang berhenti
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+}
+
+func (r *Registry) run(ctx context.Context, name string) Result {
+	r.mu.Lock()
+	check := r.checks[name]
+	cached, ok := r.cache[name]
+	r.mu.Unlock()
+
+	now := time.Now()
+	if ok && now.Before(cached.expires) {
+		return cached.result
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	err := check(checkCtx)
+	result := Result{
+		Status:    StatusUp,
+		Duration:  time.Since(now).Round(time.Microsecond).String(),
+		CheckedAt: now,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	if r.cacheTTL > 0 {
+		r.mu.Lock()
+		r.cache[name] = cachedResult{result: result, expires: now.Add(r.cacheTTL)}
+		r.mu.Unlock()
+	}
+	return result
+}
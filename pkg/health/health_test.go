@@ -0,0 +1,72 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Check(t *testing.T) {
+	registry := NewRegistry(50*time.Millisecond, 0)
+	registry.Register("database", func(ctx context.Context) error { return nil })
+	registry.Register("cache", func(ctx context.Context) error { return errors.New("connection refused") })
+	registry.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := registry.Check(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, StatusDown, report.Checks["cache"].Status)
+	assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+
+	// Pengecekan yang tidak selesai dihentikan oleh timeout
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	var calls atomic.Int32
+	registry := NewRegistry(time.Second, time.Hour)
+	registry.Register("database", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	assert.True(t, registry.Check(context.Background()).Healthy())
+	assert.True(t, registry.Check(context.Background()).Healthy())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRegistry_Shutdown(t *testing.T) {
+	registry := NewRegistry(time.Second, 0)
+	registry.Register("database", func(ctx context.Context) error { return nil })
+	assert.True(t, registry.Ready(context.Background()).Healthy())
+
+	registry.Shutdown()
+	report := registry.Ready(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Equal(t, ErrShuttingDown.Error(), report.Checks["shutdown"].Error)
+
+	// Dependency tetap dapat diperiksa, misalnya untuk liveness
+	assert.True(t, registry.Check(context.Background()).Healthy())
+}
+
+func TestReport_Redacted(t *testing.T) {
+	report := Report{Status: StatusDown, Checks: map[string]Result{
+		"database": {Status: StatusDown, Error: "password authentication failed for user \"app\""},
+	}}
+
+	redacted := report.Redacted()
+	assert.Equal(t, StatusDown, redacted.Status)
+	assert.Equal(t, StatusDown, redacted.Checks["database"].Status)
+	assert.Empty(t, redacted.Checks["database"].Error)
+
+	// Report asli tidak berubah
+	assert.NotEmpty(t, report.Checks["database"].Error)
+}
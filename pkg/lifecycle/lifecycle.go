@@ -0,0 +1,199 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Server adalah komponen yang berjalan sampai dihentikan, misalnya server
+// HTTP atau gRPC. Start memblok sampai server berhenti; Stop harus membuat
+// Start kembali dan menghormati deadline ctx.
+type Server struct {
+	Name  string
+	Start func() error
+	Stop  func(ctx context.Context) error
+}
+
+// Hook adalah satu langkah shutdown
+type Hook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+// Lifecycle menjalankan server dan mengatur urutan shutdown:
+//
+//  1. hook BeforeStop dijalankan berurutan, misalnya menandai health check
+//     tidak siap agar load balancer berhenti mengirim request
+//  2. server tetap menerima request selama drain delay, sampai load balancer
+//     dan endpoint Kubernetes melihat perubahan readiness
+//  3. semua server dihentikan bersamaan dan request yang sedang berjalan
+//     ditunggu sampai selesai
+//  4. hook AfterStop dijalankan dengan urutan terbalik dari pendaftaran,
+//     seperti defer, sehingga resource yang dibuka pertama (misalnya
+//     koneksi database) ditutup paling akhir
+//
+// Seluruh shutdown, termasuk drain delay, dibatasi oleh shutdownTimeout.
+// Drain delay dilewati jika Run berhenti karena salah satu server gagal.
+type Lifecycle struct {
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	signals         []os.Signal
+	log             *zap.Logger
+
+	servers     []Server
+	beforeStop  []Hook
+	afterStop   []Hook
+	shutdownMux sync.Mutex
+}
+
+// New membuat instance baru dari Lifecycle yang berhenti saat menerima
+// SIGINT atau SIGTERM. Logger nil berarti tidak ada log yang ditulis.
+func New(shutdownTimeout time.Duration, log *zap.Logger) *Lifecycle {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return &Lifecycle{
+		shutdownTimeout: shutdownTimeout,
+		signals:         []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		log:             log,
+	}
+}
+
+// SetDrainDelay mengatur jeda antara hook BeforeStop dan penghentian server.
+// Jeda dilewati jika belum ada server yang didaftarkan.
+func (l *Lifecycle) SetDrainDelay(delay time.Duration) {
+	l.drainDelay = delay
+}
+
+// AddServer mendaftarkan server yang dijalankan oleh Run
+func (l *Lifecycle) AddServer(server Server) {
+	l.servers = append(l.servers, server)
+}
+
+// BeforeStop mendaftarkan hook yang dijalankan sebelum server dihentikan
+func (l *Lifecycle) BeforeStop(name string, fn func(ctx context.Context) error) {
+	l.beforeStop = append(l.beforeStop, Hook{Name: name, Fn: fn})
+}
+
+// AfterStop mendaftarkan hook yang dijalankan setelah semua server berhenti.
+// Hook yang didaftarkan terakhir dijalankan pertama.
+func (l *Lifecycle) AfterStop(name string, fn func(ctx context.Context) error) {
+	l.afterStop = append([]Hook{{Name: name, Fn: fn}}, l.afterStop...)
+}
+
+// Run menjalankan semua server lalu menunggu sinyal, pembatalan ctx, atau
+// salah satu server berhenti dengan error. Setelah itu shutdown dijalankan
+// dan Run mengembalikan gabungan error yang terjadi.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, l.signals...)
+	defer stop()
+
+	serverErrs := make(chan error, len(l.servers))
+	for _, server := range l.servers {
+		server := server
+		go func() {
+			l.log.Info("starting server", zap.String("server", server.Name))
+			if err := server.Start(); err != nil {
+				serverErrs <- fmt.Errorf("%s: %w", server.Name, err)
+				return
+			}
+			serverErrs <- nil
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		l.log.Info("shutdown signal received")
+	case runErr = <-serverErrs:
+		if runErr != nil {
+			l.log.Error("server stopped unexpectedly", zap.Error(runErr))
+		}
+	}
+
+	// Jika server gagal, proses sudah tidak melayani dengan benar sehingga
+	// drain delay hanya menunda restart
+	return errors.Join(runErr, l.shutdown(runErr == nil))
+}
+
+// Shutdown menjalankan urutan shutdown. Aman dipanggil langsung tanpa Run,
+// misalnya ketika inisialisasi gagal setelah sebagian dependency dibuka, dan
+// pemanggilan berikutnya tidak melakukan apa-apa.
+func (l *Lifecycle) Shutdown() error {
+	return l.shutdown(true)
+}
+
+func (l *Lifecycle) shutdown(drain bool) error {
+	l.shutdownMux.Lock()
+	defer l.shutdownMux.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	errs = append(errs, runHooks(ctx, l.beforeStop)...)
+	if drain {
+		l.drain(ctx)
+	}
+	errs = append(errs, l.stopServers(ctx)...)
+	errs = append(errs, runHooks(ctx, l.afterStop)...)
+
+	l.beforeStop, l.servers, l.afterStop = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// drain menunggu drainDelay atau sampai ctx berakhir
+func (l *Lifecycle) drain(ctx context.Context) {
+	if len(l.servers) == 0 || l.drainDelay <= 0 {
+		return
+	}
+	l.log.Info("draining before stopping servers", zap.Duration("delay", l.drainDelay))
+
+	timer := time.NewTimer(l.drainDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+func (l *Lifecycle) stopServers(ctx context.Context) []error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, server := range l.servers {
+		server := server
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.log.Info("stopping server", zap.String("server", server.Name))
+			if err := server.Stop(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("error stopping %s: %w", server.Name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func runHooks(ctx context.Context, hooks []Hook) []error {
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook.Fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error running shutdown hook %s: %w", hook.Name, err))
+		}
+	}
+	return errs
+}
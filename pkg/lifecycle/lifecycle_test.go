@@ -0,0 +1,207 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder mencatat urutan langkah shutdown
+type recorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *recorder) add(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *recorder) hook(step string) func(context.Context) error {
+	return func(context.Context) error {
+		r.add(step)
+		return nil
+	}
+}
+
+func blockingServer(name string, rec *recorder) Server {
+	done := make(chan struct{})
+	return Server{
+		Name: name,
+		Start: func() error {
+			<-done
+			return nil
+		},
+		Stop: func(context.Context) error {
+			rec.add("stop " + name)
+			close(done)
+			return nil
+		},
+	}
+}
+
+func TestLifecycle_ShutdownOrder(t *testing.T) {
+	rec := &recorder{}
+	app := New(time.Second, nil)
+	app.AfterStop("database", rec.hook("close database"))
+	app.AfterStop("jobs", rec.hook("stop jobs"))
+	app.BeforeStop("health", rec.hook("not serving"))
+	app.AddServer(blockingServer("http", rec))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- app.Run(ctx) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after context was cancelled")
+	}
+
+	assert.Equal(t, []string{"not serving", "stop http", "stop jobs", "close database"}, rec.steps)
+
+	// Shutdown berikutnya tidak menjalankan hook lagi
+	require.NoError(t, app.Shutdown())
+	assert.Len(t, rec.steps, 4)
+}
+
+func TestLifecycle_ServerError(t *testing.T) {
+	rec := &recorder{}
+	app := New(time.Second, nil)
+	app.AfterStop("database", rec.hook("close database"))
+	app.AddServer(Server{
+		Name:  "broken",
+		Start: func() error { return errors.New("address already in use") },
+		Stop:  func(context.Context) error { return nil },
+	})
+
+	err := app.Run(context.Background())
+	assert.ErrorContains(t, err, "broken: address already in use")
+	assert.Equal(t, []string{"close database"}, rec.steps)
+}
+
+func TestLifecycle_HookErrors(t *testing.T) {
+	app := New(time.Second, nil)
+	app.AfterStop("database", func(context.Context) error { return errors.New("close failed") })
+	app.AfterStop("jobs", func(context.Context) error { return nil })
+
+	err := app.Shutdown()
+	assert.ErrorContains(t, err, "database: close failed")
+}
+
+func TestLifecycle_DrainDelay(t *testing.T) {
+	var notReady, stopped time.Time
+	done := make(chan struct{})
+	app := New(time.Second, nil)
+	app.SetDrainDelay(200 * time.Millisecond)
+	app.BeforeStop("health", func(context.Context) error {
+		notReady = time.Now()
+		return nil
+	})
+	app.AddServer(Server{
+		Name: "http",
+		Start: func() error {
+			<-done
+			return nil
+		},
+		Stop: func(context.Context) error {
+			stopped = time.Now()
+			close(done)
+			return nil
+		},
+	})
+
+	require.NoError(t, app.Shutdown())
+	assert.GreaterOrEqual(t, stopped.Sub(notReady), 200*time.Millisecond)
+}
+
+func TestLifecycle_DrainDelayBoundedByTimeout(t *testing.T) {
+	rec := &recorder{}
+	app := New(100*time.Millisecond, nil)
+	app.SetDrainDelay(time.Minute)
+	app.AddServer(blockingServer("http", rec))
+
+	start := time.Now()
+	require.NoError(t, app.Shutdown())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"stop http"}, rec.steps)
+
+	// Tanpa server tidak ada yang perlu di-drain
+	app = New(time.Minute, nil)
+	app.SetDrainDelay(time.Minute)
+	start = time.Now()
+	require.NoError(t, app.Shutdown())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestLifecycle_ServerErrorSkipsDrainDelay(t *testing.T) {
+	rec := &recorder{}
+	app := New(time.Minute, nil)
+	app.SetDrainDelay(time.Minute)
+	app.BeforeStop("health", rec.hook("not serving"))
+	app.AddServer(blockingServer("grpc", rec))
+	app.AddServer(Server{
+		Name:  "broken",
+		Start: func() error { return errors.New("address already in use") },
+		Stop:  func(context.Context) error { return nil },
+	})
+
+	start := time.Now()
+	err := app.Run(context.Background())
+	assert.ErrorContains(t, err, "broken: address already in use")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"not serving", "stop grpc"}, rec.steps)
+}
+
+func TestHTTPServer_DrainsInFlightRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	})}
+
+	app := New(5*time.Second, nil)
+	wrapped := HTTPServer("http", server)
+	wrapped.Start = func() error {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+	app.AddServer(wrapped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- app.Run(ctx) }()
+
+	status := make(chan int)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			status <- 0
+			return
+		}
+		resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	assert.Equal(t, http.StatusNoContent, <-status)
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,51 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// HTTPServer membungkus *http.Server. Shutdown menunggu request yang sedang
+// berjalan selesai sampai deadline ctx.
+func HTTPServer(name string, server *http.Server) Server {
+	return Server{
+		Name: name,
+		Start: func() error {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: server.Shutdown,
+	}
+}
+
+// GRPCServer membungkus *grpc.Server yang melayani listener. GracefulStop
+// dipakai sampai deadline ctx, lalu server dihentikan paksa.
+func GRPCServer(name string, server *grpc.Server, listener net.Listener) Server {
+	return Server{
+		Name: name,
+		Start: func() error {
+			return server.Serve(listener)
+		},
+		Stop: func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
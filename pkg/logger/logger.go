@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	EncodingJSON    = "json"
+	EncodingConsole = "console"
+)
+
+// New membuat *zap.Logger dari konfigurasi logger. Encoding json cocok untuk
+// production karena dapat dibaca oleh log collector, sedangkan console lebih
+// mudah dibaca saat development.
+func New(cfg config.LoggerConfig) (*zap.Logger, error) {
+	level := zapcore.InfoLevel
+	if cfg.Level != "" {
+		parsed, err := zapcore.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+		level = parsed
+	}
+
+	zapCfg := zap.NewProductionConfig()
+	switch cfg.Encoding {
+	case "", EncodingJSON:
+		zapCfg.EncoderConfig.TimeKey = "time"
+		zapCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	case EncodingConsole:
+		zapCfg.Encoding = EncodingConsole
+		zapCfg.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+		zapCfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	default:
+		return nil, fmt.Errorf("unsupported log encoding %q", cfg.Encoding)
+	}
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+
+	// Sampling membatasi log identik per detik: SamplingInitial baris pertama
+	// selalu ditulis, lalu hanya setiap baris ke-SamplingThereafter
+	zapCfg.Sampling = nil
+	if cfg.SamplingInitial > 0 && cfg.SamplingThereafter > 0 {
+		zapCfg.Sampling = &zap.SamplingConfig{
+			Initial:    cfg.SamplingInitial,
+			Thereafter: cfg.SamplingThereafter,
+		}
+	}
+
+	logger, err := zapCfg.Build()
+	if err != nil {
+		return nil, fmt.Errorf("error building logger: %w", err)
+	}
+	return logger, nil
+}
+
+type loggerKey struct{}
+
+// NewContext menyimpan logger ke dalam context. Logger ini biasanya sudah
+// berisi field milik request, misalnya request id dan user id.
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext mengambil logger milik request dari context, atau logger
+// global (zap.L) jika context tidak membawa logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok && logger != nil {
+		return logger
+	}
+	return zap.L()
+}
+
+// With menambahkan field pada logger di dalam context dan mengembalikan
+// context baru yang membawa logger tersebut
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(fields...))
+}
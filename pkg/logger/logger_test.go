@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNew(t *testing.T) {
+	logger, err := New(config.LoggerConfig{Level: "warn", Encoding: EncodingJSON})
+	require.NoError(t, err)
+	assert.False(t, logger.Core().Enabled(zapcore.InfoLevel))
+	assert.True(t, logger.Core().Enabled(zapcore.WarnLevel))
+
+	logger, err = New(config.LoggerConfig{Encoding: EncodingConsole, SamplingInitial: 100, SamplingThereafter: 100})
+	require.NoError(t, err)
+	assert.True(t, logger.Core().Enabled(zapcore.InfoLevel))
+
+	_, err = New(config.LoggerConfig{Level: "verbose"})
+	assert.Error(t, err)
+
+	_, err = New(config.LoggerConfig{Encoding: "xml"})
+	assert.Error(t, err)
+}
+
+func TestContext(t *testing.T) {
+	// Tanpa logger di context, logger global yang dipakai
+	assert.Same(t, zap.L(), FromContext(context.Background()))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	ctx := NewContext(context.Background(), zap.New(core))
+	ctx = With(ctx, zap.String("request_id", "req-1"))
+
+	FromContext(ctx).Info("hello")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "req-1", logs.All()[0].ContextMap()["request_id"])
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sekolahmu/boilerplate-go/internal/domain/apperror"
+	"google.golang.org/grpc/codes"
+)
+
+// Metrics menyimpan metric RED (rate, error, duration) untuk HTTP, gRPC dan
+// query repository pada registry milik aplikasi. Label route dan method
+// memakai template (misalnya /api/v1/users/:id) agar jumlah time series
+// tetap terbatas.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	grpcRequests  *prometheus.CounterVec
+	grpcDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+}
+
+// New membuat instance baru dari Metrics beserta collector runtime Go dan
+// process
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "Total number of RPCs completed on the server by method and status code.",
+		}, []string{"grpc_method", "grpc_code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Duration of RPCs handled by the server by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"grpc_method"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of repository operations by operation and outcome.",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.grpcRequests,
+		m.grpcDuration,
+		m.queryDuration,
+	)
+	return m
+}
+
+// Handler mengembalikan handler HTTP untuk endpoint /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry mengembalikan registry milik Metrics, misalnya untuk metric
+// tambahan atau pengujian
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// RegisterDBStats mendaftarkan sql.DBStats (koneksi open, in-use, idle,
+// wait count, ...) sebagai gauge dengan label db_name
+func (m *Metrics) RegisterDBStats(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveHTTP mencatat satu request HTTP
+func (m *Metrics) ObserveHTTP(method, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGRPC mencatat satu RPC
+func (m *Metrics) ObserveGRPC(method string, code codes.Code, duration time.Duration) {
+	m.grpcRequests.WithLabelValues(method, code.String()).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// ObserveQuery mencatat satu operasi repository, misalnya "users.Create".
+// Error domain seperti user tidak ditemukan dihitung sebagai hasil yang
+// normal; hanya error internal yang dihitung sebagai error.
+func (m *Metrics) ObserveQuery(operation string, duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil && apperror.KindOf(err) == apperror.KindInternal {
+		outcome = "error"
+	}
+	m.queryDuration.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
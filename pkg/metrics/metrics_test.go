@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sekolahmu/boilerplate-go/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// stubConnector adalah driver.Connector yang tidak pernah dipakai untuk query
+type stubConnector struct{}
+
+func (stubConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stubConnector) Driver() driver.Driver { return nil }
+
+func scrape(t *testing.T, m *Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTP(http.MethodGet, "/api/v1/users/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTP(http.MethodGet, "/api/v1/users/:id", http.StatusNotFound, 5*time.Millisecond)
+	m.ObserveGRPC("/user.v1.UserService/GetUser", codes.NotFound, time.Millisecond)
+	m.ObserveQuery("users.GetByID", time.Millisecond, entity.ErrUserNotFound)
+	m.ObserveQuery("users.Create", time.Millisecond, errors.New("connection refused"))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/v1/users/:id",status="200"} 1`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/v1/users/:id",status="404"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/api/v1/users/:id"} 2`)
+	assert.Contains(t, body, `grpc_server_handled_total{grpc_code="NotFound",grpc_method="/user.v1.UserService/GetUser"} 1`)
+	// Error domain tidak dihitung sebagai error query
+	assert.Contains(t, body, `db_query_duration_seconds_count{operation="users.GetByID",outcome="ok"} 1`)
+	assert.Contains(t, body, `db_query_duration_seconds_count{operation="users.Create",outcome="error"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestMetrics_RegisterDBStats(t *testing.T) {
+	m := New()
+
+	// sql.OpenDB tidak membuka koneksi, sehingga stats dapat dibaca tanpa database
+	db := sql.OpenDB(stubConnector{})
+	defer db.Close()
+
+	require.NoError(t, m.RegisterDBStats(db, "boilerplate"))
+	body := scrape(t, m)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="boilerplate"} 0`)
+	assert.Contains(t, body, `go_sql_in_use_connections{db_name="boilerplate"} 0`)
+	assert.Contains(t, body, `go_sql_wait_count_total{db_name="boilerplate"} 0`)
+}
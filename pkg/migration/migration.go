@@ -0,0 +1,199 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/sekolahmu/boilerplate-go/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidName = errors.New("migration name must contain only letters, digits and underscores")
+	ErrNoVersion   = errors.New("no migration has been applied")
+)
+
+// versionDigits adalah jumlah digit nomor urut migrasi, mengikuti file yang
+// sudah ada (000001_create_users_table.up.sql)
+const versionDigits = 6
+
+var (
+	namePattern    = regexp.MustCompile(`^[a-z0-9_]+$`)
+	versionPattern = regexp.MustCompile(`^(\d+)_.+\.(up|down)\.sql$`)
+)
+
+// Migrator menjalankan migrasi dari fs.FS ke database PostgreSQL.
+//
+// Setiap perintah dijalankan di bawah advisory lock PostgreSQL milik
+// golang-migrate, sehingga beberapa replika yang menjalankan migrasi saat
+// start tidak saling bertabrakan: satu replika menjalankan migrasi dan yang
+// lain menunggu sampai lock dilepas (paling lama lockTimeout), lalu mendapati
+// database sudah pada versi terbaru.
+type Migrator struct {
+	migrate *migrate.Migrate
+	conn    *sql.Conn
+}
+
+// New membuat instance baru dari Migrator. Migrator memakai satu koneksi
+// khusus dari db agar lock dan migrasi berjalan pada session yang sama;
+// Close hanya menutup koneksi tersebut, bukan db.
+func New(ctx context.Context, db *sql.DB, source fs.FS, lockTimeout time.Duration) (*Migrator, error) {
+	sourceDriver, err := iofs.New(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error acquiring migration connection: %w", err)
+	}
+
+	// DDL pada tabel besar dapat berjalan lebih lama dari statement_timeout
+	// yang berlaku untuk request aplikasi. Close mengembalikan nilainya
+	// sebelum koneksi kembali ke pool.
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error disabling statement timeout: %w", err)
+	}
+
+	dbDriver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error initializing migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", dbDriver)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error initializing migrator: %w", err)
+	}
+	if lockTimeout > 0 {
+		m.LockTimeout = lockTimeout
+	}
+	m.Log = &migrateLogger{log: logger.FromContext(ctx)}
+
+	return &Migrator{migrate: m, conn: conn}, nil
+}
+
+// Up menjalankan semua migrasi yang belum diterapkan
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.migrate.Up())
+}
+
+// Down membatalkan steps migrasi terakhir
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("invalid number of steps: %d", steps)
+	}
+	return ignoreNoChange(m.migrate.Steps(-steps))
+}
+
+// Goto menjalankan migrasi naik atau turun sampai versi tertentu
+func (m *Migrator) Goto(version uint) error {
+	return ignoreNoChange(m.migrate.Migrate(version))
+}
+
+// Version mengembalikan versi migrasi saat ini dan apakah database dalam
+// keadaan dirty karena migrasi sebelumnya gagal di tengah jalan
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, ErrNoVersion
+	}
+	return version, dirty, err
+}
+
+// Force menandai database pada versi tertentu tanpa menjalankan migrasi dan
+// menghapus status dirty. Versi -1 berarti belum ada migrasi yang diterapkan.
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// Close mengembalikan statement_timeout session ke nilai dari DSN lalu
+// melepas koneksi migrasi ke pool milik db. Jika reset gagal, koneksi dibuang
+// agar tidak dipakai aplikasi tanpa statement_timeout.
+func (m *Migrator) Close() error {
+	_, resetErr := m.conn.ExecContext(context.Background(), "RESET statement_timeout")
+	if resetErr != nil {
+		resetErr = fmt.Errorf("error resetting statement timeout: %w", resetErr)
+		_ = m.conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	sourceErr, dbErr := m.migrate.Close()
+	if resetErr != nil {
+		// Koneksi sudah dibuang di atas, sehingga error saat menutupnya
+		// tidak menambah informasi
+		dbErr = nil
+	}
+	return errors.Join(resetErr, sourceErr, dbErr)
+}
+
+// Create membuat pasangan file migrasi kosong dengan nomor urut berikutnya di
+// dir dan mengembalikan path kedua file
+func Create(dir, name string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !namePattern.MatchString(name) {
+		return "", "", ErrInvalidName
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("error reading migration directory: %w", err)
+	}
+
+	var next uint64 = 1
+	for _, entry := range entries {
+		match := versionPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err == nil && version >= next {
+			next = version + 1
+		}
+	}
+
+	base := fmt.Sprintf("%0*d_%s", versionDigits, next, name)
+	up := filepath.Join(dir, base+".up.sql")
+	down := filepath.Join(dir, base+".down.sql")
+	for _, path := range []string{up, down} {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("error creating migration file: %w", err)
+		}
+		file.Close()
+	}
+	return up, down, nil
+}
+
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrateLogger meneruskan log golang-migrate ke zap
+type migrateLogger struct {
+	log *zap.Logger
+}
+
+func (l *migrateLogger) Printf(format string, v ...any) {
+	l.log.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l *migrateLogger) Verbose() bool {
+	return false
+}
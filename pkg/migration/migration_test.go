@@ -0,0 +1,160 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/sekolahmu/boilerplate-go/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	source, err := iofs.New(migrations.FS, ".")
+	require.NoError(t, err)
+	defer source.Close()
+
+	// Setiap versi harus memiliki file up dan down
+	version, err := source.First()
+	require.NoError(t, err)
+	count := 0
+	for {
+		count++
+		up, _, err := source.ReadUp(version)
+		require.NoError(t, err, "version %d", version)
+		up.Close()
+		down, _, err := source.ReadDown(version)
+		require.NoError(t, err, "version %d", version)
+		down.Close()
+
+		version, err = source.Next(version)
+		if err != nil {
+			break
+		}
+	}
+
+	files, err := migrations.FS.ReadDir(".")
+	require.NoError(t, err)
+	assert.Equal(t, len(files), count*2)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "000007_create_posts.up.sql"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), nil, 0o644))
+
+	up, down, err := Create(dir, "Add_Index_To_Posts")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "000008_add_index_to_posts.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "000008_add_index_to_posts.down.sql"), down)
+	assert.FileExists(t, up)
+	assert.FileExists(t, down)
+
+	_, _, err = Create(dir, "drop table; --")
+	assert.ErrorIs(t, err, ErrInvalidName)
+
+	_, _, err = Create(filepath.Join(dir, "missing"), "add_column")
+	assert.True(t, err != nil && strings.Contains(err.Error(), "error reading migration directory"))
+}
+
+// recordingConnector adalah driver database/sql yang mencatat statement yang
+// dijalankan dan jumlah koneksi yang ditutup
+type recordingConnector struct {
+	mu       sync.Mutex
+	execs    []string
+	closed   int
+	failExec string
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+type recordingConn struct {
+	connector *recordingConnector
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.execs = append(c.connector.execs, query)
+	if query == c.connector.failExec {
+		return nil, errors.New("exec failed")
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *recordingConn) Close() error {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.closed++
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+// connStub adalah driver migrasi stub yang, seperti driver postgres, menutup
+// koneksinya saat Close
+type connStub struct {
+	stub.Stub
+	conn *sql.Conn
+}
+
+func (s *connStub) Close() error {
+	return s.conn.Close()
+}
+
+func newTestMigrator(t *testing.T, connector *recordingConnector) (*Migrator, *sql.DB) {
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	source, err := iofs.New(migrations.FS, ".")
+	require.NoError(t, err)
+	m, err := migrate.NewWithInstance("iofs", source, "stub", &connStub{conn: conn})
+	require.NoError(t, err)
+	return &Migrator{migrate: m, conn: conn}, db
+}
+
+func TestMigrator_CloseResetsStatementTimeout(t *testing.T) {
+	connector := &recordingConnector{}
+	m, db := newTestMigrator(t, connector)
+
+	require.NoError(t, m.Close())
+	assert.Equal(t, []string{"RESET statement_timeout"}, connector.execs)
+
+	// Koneksi kembali ke pool, bukan ditutup
+	assert.Equal(t, 0, connector.closed)
+	assert.Equal(t, 1, db.Stats().Idle)
+}
+
+func TestMigrator_CloseDiscardsConnectionWhenResetFails(t *testing.T) {
+	connector := &recordingConnector{failExec: "RESET statement_timeout"}
+	m, db := newTestMigrator(t, connector)
+
+	err := m.Close()
+	assert.ErrorContains(t, err, "error resetting statement timeout")
+
+	// Koneksi dengan statement_timeout = 0 tidak dikembalikan ke pool
+	assert.Equal(t, 1, connector.closed)
+	assert.Equal(t, 0, db.Stats().OpenConnections)
+}
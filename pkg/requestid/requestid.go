@@ -0,0 +1,60 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header adalah header HTTP yang membawa id request
+	Header = "X-Request-ID"
+	// MetadataKey adalah key metadata gRPC yang membawa id request
+	MetadataKey = "x-request-id"
+	// maxLength membatasi panjang id request dari client
+	maxLength = 128
+)
+
+type requestIDKey struct{}
+
+// New membuat id request baru
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid memeriksa id request yang dikirim client. Hanya huruf, angka, dan
+// karakter "-", "_", ".", ":" yang diterima agar id aman ditulis ke log,
+// header, dan komentar SQL.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve mengembalikan id dari client jika valid, atau id baru jika tidak
+func Resolve(id string) string {
+	if Valid(id) {
+		return id
+	}
+	return New()
+}
+
+// NewContext menyimpan id request ke dalam context
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext mengambil id request dari context
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
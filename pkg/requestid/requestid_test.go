@@ -0,0 +1,44 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"3f2c7a9e-0d4b-4c59-9a51-1e8f6f0b2d11", true},
+		{"trace:abc_123.4", true},
+		{"", false},
+		{strings.Repeat("a", maxLength+1), false},
+		{"abc */ DROP TABLE users; /*", false},
+		{"line\nbreak", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, Valid(tt.id), tt.id)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, "client-id", Resolve("client-id"))
+
+	generated := Resolve("not valid")
+	assert.NotEqual(t, "not valid", generated)
+	assert.True(t, Valid(generated))
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	id, ok := FromContext(NewContext(context.Background(), "req-1"))
+	assert.True(t, ok)
+	assert.Equal(t, "req-1", id)
+}
@@ -0,0 +1,140 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Provider adalah TracerProvider aplikasi yang harus di-Shutdown saat
+// aplikasi berhenti agar span yang masih di-buffer terkirim
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New membuat TracerProvider sesuai konfigurasi lalu memasangnya sebagai
+// provider global beserta propagator W3C traceparent dan baggage. Propagator
+// tetap dipasang ketika exporter "none" agar trace dari client tetap
+// diteruskan ke service berikutnya.
+func New(ctx context.Context, cfg config.TracingConfig, app config.AppConfig) (*Provider, error) {
+	return newProvider(ctx, cfg, app, os.Stdout)
+}
+
+func newProvider(ctx context.Context, cfg config.TracingConfig, app config.AppConfig, stdout io.Writer) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(
+			semconv.ServiceName(app.Name),
+			semconv.ServiceVersion(app.Version),
+			semconv.DeploymentEnvironment(app.Env),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		// Tanpa exporter span tetap dibuat sehingga trace id tersedia untuk
+		// log dan diteruskan ke service lain, tetapi tidak dikirim ke mana pun
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(stdout))
+		if err != nil {
+			return nil, fmt.Errorf("error creating stdout trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	return &Provider{provider: provider}, nil
+}
+
+// Shutdown mengirim span yang tersisa lalu menghentikan provider
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error shutting down tracer provider: %w", err)
+	}
+	return nil
+}
+
+// Transport adalah http.RoundTripper yang menambahkan header traceparent
+// dari context request, untuk client HTTP yang memanggil service lain
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip menyalin request lalu menambahkan header propagasi trace
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	req = req.Clone(req.Context())
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	return base.RoundTrip(req)
+}
+
+// Middleware membuat span server untuk setiap request HTTP dengan traceparent
+// dari header request sebagai span induk
+func Middleware(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service)
+}
+
+// ServerOption membuat span server untuk setiap RPC dengan traceparent dari
+// metadata request sebagai span induk
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// DialOptions membuat span client untuk setiap RPC keluar dan menambahkan
+// traceparent dari context ke metadata, untuk client gRPC yang memanggil
+// service lain
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+}
@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sekolahmu/boilerplate-go/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var testApp = config.AppConfig{Name: "boilerplate-go", Version: "test", Env: "test"}
+
+func TestNew_Stdout(t *testing.T) {
+	var out bytes.Buffer
+	provider, err := newProvider(context.Background(), config.TracingConfig{Exporter: ExporterStdout, SampleRatio: 1}, testApp, &out)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	assert.Contains(t, out.String(), `"Name":"test-span"`)
+	assert.Contains(t, out.String(), "boilerplate-go")
+}
+
+func TestNew_InvalidExporter(t *testing.T) {
+	_, err := New(context.Background(), config.TracingConfig{Exporter: "zipkin"}, testApp)
+	assert.Error(t, err)
+}
+
+func TestTransport(t *testing.T) {
+	provider, err := New(context.Background(), config.TracingConfig{Exporter: ExporterNone, SampleRatio: 1}, testApp)
+	require.NoError(t, err)
+	defer provider.Shutdown(context.Background())
+
+	// Traceparent dari request masuk diteruskan pada request keluar
+	incoming := http.Header{}
+	incoming.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(incoming))
+	ctx, span := otel.Tracer("test").Start(ctx, "outbound")
+	defer span.End()
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: &Transport{}}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Contains(t, received, "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Empty(t, req.Header.Get("traceparent"), "request asli tidak boleh diubah")
+}
+
+const (
+	incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	incomingSpanID  = "00f067aa0ba902b7"
+	traceparent     = "00-" + incomingTraceID + "-" + incomingSpanID + "-01"
+)
+
+// recordSpans memasang provider global yang mencatat span selama test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// spanOfKind mengembalikan span pertama yang sudah berakhir dengan kind tersebut
+func spanOfKind(t *testing.T, recorder *tracetest.SpanRecorder, kind trace.SpanKind) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.SpanKind() == kind {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no ended span of kind %s", kind)
+	return nil
+}
+
+func startGRPCServer(t *testing.T, opts ...grpc.ServerOption) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener
+}
+
+func dialBufconn(t *testing.T, listener *bufconn.Listener, opts ...grpc.DialOption) *grpc.ClientConn {
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.DialContext(context.Background(), "bufnet", opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := recordSpans(t)
+	gin.SetMode(gin.TestMode)
+
+	var handlerTraceID string
+	router := gin.New()
+	router.Use(Middleware("boilerplate-go"))
+	router.GET("/ping", func(c *gin.Context) {
+		handlerTraceID = trace.SpanContextFromContext(c.Request.Context()).TraceID().String()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := spanOfKind(t, recorder, trace.SpanKindServer)
+	assert.Equal(t, incomingTraceID, handlerTraceID)
+	assert.Equal(t, incomingTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, incomingSpanID, span.Parent().SpanID().String())
+	assert.True(t, span.Parent().IsRemote())
+}
+
+func TestServerOption(t *testing.T) {
+	recorder := recordSpans(t)
+	conn := dialBufconn(t, startGRPCServer(t, ServerOption()))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", traceparent)
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	span := spanOfKind(t, recorder, trace.SpanKindServer)
+	assert.Equal(t, "grpc.health.v1.Health/Check", span.Name())
+	assert.Equal(t, incomingTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, incomingSpanID, span.Parent().SpanID().String())
+}
+
+func TestDialOptions(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var received []string
+	capture := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		received = md.Get("traceparent")
+		return handler(ctx, req)
+	}
+	conn := dialBufconn(t, startGRPCServer(t, grpc.UnaryInterceptor(capture)), DialOptions()...)
+
+	// Trace dari request masuk diteruskan pada RPC keluar dengan span client
+	// sebagai induk di service berikutnya
+	incoming := http.Header{}
+	incoming.Set("traceparent", traceparent)
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(incoming))
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	span := spanOfKind(t, recorder, trace.SpanKindClient)
+	assert.Equal(t, incomingSpanID, span.Parent().SpanID().String())
+	require.Len(t, received, 1)
+	assert.Equal(t, "00-"+incomingTraceID+"-"+span.SpanContext().SpanID().String()+"-01", received[0])
+}